@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// renderBlocks renders Block Kit rich_text blocks into compact Markdown.
+// Other block types are skipped. Mentions are kept as Slack tokens such as <@U123> so resolve_mentions can expand them.
+func renderBlocks(blocks slack.Blocks) string {
+	var parts []string
+	for _, block := range blocks.BlockSet {
+		var elements []slack.RichTextElement
+		switch b := block.(type) {
+		case *slack.RichTextBlock:
+			elements = b.Elements
+		case slack.RichTextBlock:
+			elements = b.Elements
+		default:
+			continue
+		}
+		if rendered := renderRichTextElements(elements); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// messageText returns the message text, falling back to the rendered blocks when text is empty or truncated
+func messageText(text string, blocks slack.Blocks) string {
+	rendered := renderBlocks(blocks)
+	if rendered == "" {
+		return text
+	}
+	if strings.TrimSpace(text) == "" {
+		return rendered
+	}
+	if isTruncatedText(text) && len(rendered) > len(text) {
+		return rendered
+	}
+	return text
+}
+
+// isTruncatedText reports whether text looks like a shortened fallback of a longer message
+func isTruncatedText(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	return strings.HasSuffix(trimmed, "…") || strings.HasSuffix(trimmed, "...")
+}
+
+func renderRichTextElements(elements []slack.RichTextElement) string {
+	var parts []string
+	for _, element := range elements {
+		var rendered string
+		switch e := element.(type) {
+		case *slack.RichTextSection:
+			rendered = renderRichTextSection(e.Elements)
+		case slack.RichTextSection:
+			rendered = renderRichTextSection(e.Elements)
+		case *slack.RichTextList:
+			rendered = renderRichTextList(e)
+		case slack.RichTextList:
+			rendered = renderRichTextList(&e)
+		case *slack.RichTextPreformatted:
+			rendered = "```\n" + strings.TrimRight(renderPlainRichTextSection(e.Elements), "\n") + "\n```"
+		case *slack.RichTextQuote:
+			rendered = renderQuote(renderRichTextSection(e.Elements))
+		default:
+			continue
+		}
+		rendered = strings.TrimRight(rendered, "\n")
+		if rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderRichTextList renders list items, indenting nested lists by their indent level
+func renderRichTextList(list *slack.RichTextList) string {
+	indent := strings.Repeat("    ", list.Indent)
+	var lines []string
+	for i, item := range list.Elements {
+		var text string
+		switch e := item.(type) {
+		case *slack.RichTextSection:
+			text = renderRichTextSection(e.Elements)
+		case slack.RichTextSection:
+			text = renderRichTextSection(e.Elements)
+		default:
+			continue
+		}
+
+		marker := "- "
+		if list.Style == slack.RTEListOrdered {
+			marker = fmt.Sprintf("%d. ", list.Offset+i+1)
+		}
+		// Continuation lines of a multi-line item are aligned under the item text
+		continuation := "\n" + indent + strings.Repeat(" ", len(marker))
+		text = strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", continuation)
+		lines = append(lines, indent+marker+text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderQuote(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderRichTextSection renders inline elements with Markdown styling
+func renderRichTextSection(elements []slack.RichTextSectionElement) string {
+	var b strings.Builder
+	for _, element := range elements {
+		text, style := renderRichTextSectionElement(element)
+		b.WriteString(applyTextStyle(text, style))
+	}
+	return b.String()
+}
+
+// renderPlainRichTextSection renders inline elements without styling, for use inside code blocks
+func renderPlainRichTextSection(elements []slack.RichTextSectionElement) string {
+	var b strings.Builder
+	for _, element := range elements {
+		text, _ := renderRichTextSectionElement(element)
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+func renderRichTextSectionElement(element slack.RichTextSectionElement) (string, *slack.RichTextSectionTextStyle) {
+	switch e := element.(type) {
+	case *slack.RichTextSectionTextElement:
+		return e.Text, e.Style
+	case *slack.RichTextSectionLinkElement:
+		if e.Text == "" || e.Text == e.URL {
+			return e.URL, e.Style
+		}
+		return fmt.Sprintf("[%s](%s)", e.Text, e.URL), e.Style
+	case *slack.RichTextSectionUserElement:
+		return fmt.Sprintf("<@%s>", e.UserID), e.Style
+	case *slack.RichTextSectionChannelElement:
+		return fmt.Sprintf("<#%s>", e.ChannelID), e.Style
+	case *slack.RichTextSectionUserGroupElement:
+		return fmt.Sprintf("<!subteam^%s>", e.UsergroupID), nil
+	case *slack.RichTextSectionBroadcastElement:
+		return fmt.Sprintf("<!%s>", e.Range), nil
+	case *slack.RichTextSectionEmojiElement:
+		if e.Unicode != "" {
+			return unicodeEmoji(e.Unicode, e.Name), e.Style
+		}
+		return ":" + e.Name + ":", e.Style
+	case *slack.RichTextSectionDateElement:
+		if e.Fallback != nil && *e.Fallback != "" {
+			return *e.Fallback, nil
+		}
+		return e.Timestamp.Time().UTC().Format("2006-01-02 15:04 UTC"), nil
+	case *slack.RichTextSectionTeamElement:
+		return e.TeamID, e.Style
+	case *slack.RichTextSectionColorElement:
+		return e.Value, nil
+	}
+	return "", nil
+}
+
+// unicodeEmoji converts a code point sequence like "1f44d" or "1f1ef-1f1f5" into the emoji itself
+func unicodeEmoji(codePoints, name string) string {
+	var b strings.Builder
+	for _, cp := range strings.Split(codePoints, "-") {
+		var r rune
+		if _, err := fmt.Sscanf(cp, "%x", &r); err != nil {
+			return ":" + name + ":"
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// applyTextStyle wraps text with Markdown markers, keeping surrounding whitespace outside the markers
+func applyTextStyle(text string, style *slack.RichTextSectionTextStyle) string {
+	if style == nil {
+		return text
+	}
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+
+	if style.Code {
+		trimmed = "`" + trimmed + "`"
+	}
+	if style.Strike {
+		trimmed = "~~" + trimmed + "~~"
+	}
+	if style.Italic {
+		trimmed = "_" + trimmed + "_"
+	}
+	if style.Bold {
+		trimmed = "**" + trimmed + "**"
+	}
+	return leading + trimmed + trailing
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseBlocks(t *testing.T, raw string) slack.Blocks {
+	t.Helper()
+	var blocks slack.Blocks
+	if err := json.Unmarshal([]byte(raw), &blocks); err != nil {
+		t.Fatalf("failed to parse blocks: %v", err)
+	}
+	return blocks
+}
+
+func TestRenderBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   string
+		expected string
+	}{
+		{
+			name: "section with styles, links and mentions",
+			blocks: `[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[
+				{"type":"text","text":"Hello "},
+				{"type":"text","text":"bold ","style":{"bold":true}},
+				{"type":"text","text":"italic","style":{"italic":true}},
+				{"type":"text","text":" "},
+				{"type":"text","text":"gone","style":{"strike":true}},
+				{"type":"text","text":" "},
+				{"type":"text","text":"code","style":{"code":true}},
+				{"type":"text","text":" see "},
+				{"type":"link","url":"https://example.com","text":"docs"},
+				{"type":"text","text":" and "},
+				{"type":"link","url":"https://example.org"},
+				{"type":"text","text":" cc "},
+				{"type":"user","user_id":"U1234567"},
+				{"type":"text","text":" in "},
+				{"type":"channel","channel_id":"C1234567"},
+				{"type":"text","text":" "},
+				{"type":"usergroup","usergroup_id":"S1234567"},
+				{"type":"text","text":" "},
+				{"type":"broadcast","range":"here"},
+				{"type":"text","text":" "},
+				{"type":"emoji","name":"tada"},
+				{"type":"emoji","name":"thumbsup","unicode":"1f44d"}
+			]}]}]`,
+			expected: "Hello **bold** _italic_ ~~gone~~ `code` see [docs](https://example.com) and https://example.org cc <@U1234567> in <#C1234567> <!subteam^S1234567> <!here> :tada:👍",
+		},
+		{
+			name: "bullet and ordered lists with nesting",
+			blocks: `[{"type":"rich_text","elements":[
+				{"type":"rich_text_section","elements":[{"type":"text","text":"Todo:\n"}]},
+				{"type":"rich_text_list","style":"bullet","indent":0,"elements":[
+					{"type":"rich_text_section","elements":[{"type":"text","text":"first"}]},
+					{"type":"rich_text_section","elements":[{"type":"text","text":"second"}]}
+				]},
+				{"type":"rich_text_list","style":"ordered","indent":1,"elements":[
+					{"type":"rich_text_section","elements":[{"type":"text","text":"nested"}]}
+				]},
+				{"type":"rich_text_list","style":"ordered","indent":0,"offset":2,"elements":[
+					{"type":"rich_text_section","elements":[{"type":"text","text":"third"}]}
+				]}
+			]}]`,
+			expected: "Todo:\n- first\n- second\n    1. nested\n3. third",
+		},
+		{
+			name: "preformatted keeps text unstyled",
+			blocks: `[{"type":"rich_text","elements":[{"type":"rich_text_preformatted","elements":[
+				{"type":"text","text":"go test ./...\n"},
+				{"type":"link","url":"https://example.com"}
+			]}]}]`,
+			expected: "```\ngo test ./...\nhttps://example.com\n```",
+		},
+		{
+			name: "quote prefixes every line",
+			blocks: `[{"type":"rich_text","elements":[{"type":"rich_text_quote","elements":[
+				{"type":"text","text":"line 1\nline 2"}
+			]}]}]`,
+			expected: "> line 1\n> line 2",
+		},
+		{
+			name: "date uses fallback text",
+			blocks: `[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[
+				{"type":"text","text":"due "},
+				{"type":"date","timestamp":1392734382,"format":"{date_short}","fallback":"Feb 18, 2014"}
+			]}]}]`,
+			expected: "due Feb 18, 2014",
+		},
+		{
+			name:     "non rich_text blocks are skipped",
+			blocks:   `[{"type":"divider"},{"type":"section","text":{"type":"mrkdwn","text":"ignored"}}]`,
+			expected: "",
+		},
+		{
+			name: "multiple rich_text blocks are joined by newlines",
+			blocks: `[
+				{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"one"}]}]},
+				{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"two"}]}]}
+			]`,
+			expected: "one\ntwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, renderBlocks(mustParseBlocks(t, tt.blocks)))
+		})
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	blocks := mustParseBlocks(t, `[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[
+		{"type":"text","text":"Deploy finished for "},
+		{"type":"text","text":"api-server","style":{"bold":true}}
+	]}]}]`)
+
+	tests := []struct {
+		name     string
+		text     string
+		blocks   slack.Blocks
+		expected string
+	}{
+		{
+			name:     "empty text falls back to blocks",
+			text:     "",
+			blocks:   blocks,
+			expected: "Deploy finished for **api-server**",
+		},
+		{
+			name:     "truncated text falls back to blocks",
+			text:     "Deploy finished…",
+			blocks:   blocks,
+			expected: "Deploy finished for **api-server**",
+		},
+		{
+			name:     "complete text is kept",
+			text:     "Deploy finished for *api-server*",
+			blocks:   blocks,
+			expected: "Deploy finished for *api-server*",
+		},
+		{
+			name:     "text is kept when there are no blocks",
+			text:     "",
+			blocks:   slack.Blocks{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, messageText(tt.text, tt.blocks))
+		})
+	}
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlWhitespacePattern matches whitespace runs that HTML renders as a single space
+var htmlWhitespacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+// embeddedFilePattern extracts the file ID and URL from Slack's embedded-file paragraph text
+var embeddedFilePattern = regexp.MustCompile(`File ID:\s*(\S+)\s+File URL:\s*(\S+)`)
+
+// Slack canvas list styles stored in data-section-style
+const (
+	canvasListBullet    = "5"
+	canvasListNumbered  = "6"
+	canvasListChecklist = "7"
+)
+
+// CanvasMarkdownConverter converts Slack Canvas HTML into GitHub-flavored Markdown or plain text.
+type CanvasMarkdownConverter struct {
+	// plain drops Markdown syntax such as heading markers, emphasis and table separators
+	plain bool
+}
+
+// NewCanvasMarkdownConverter creates a converter that outputs GitHub-flavored Markdown
+func NewCanvasMarkdownConverter() *CanvasMarkdownConverter {
+	return &CanvasMarkdownConverter{}
+}
+
+// NewCanvasTextConverter creates a converter that outputs plain text
+func NewCanvasTextConverter() *CanvasMarkdownConverter {
+	return &CanvasMarkdownConverter{plain: true}
+}
+
+// Convert converts the canvas HTML. Blocks are separated by blank lines.
+func (c *CanvasMarkdownConverter) Convert(htmlStr string) (string, error) {
+	if htmlStr == "" {
+		return "", nil
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return "", err
+	}
+
+	body := NewCanvasHTMLStripper().findBody(doc)
+	if body == nil {
+		return "", nil
+	}
+
+	return strings.Join(c.renderBlocks(body), "\n\n"), nil
+}
+
+// renderBlocks renders the block-level children of n
+func (c *CanvasMarkdownConverter) renderBlocks(n *html.Node) []string {
+	var blocks []string
+	var inline strings.Builder
+
+	// Loose inline content between blocks becomes its own paragraph
+	flushInline := func() {
+		if text := normalizeInline(inline.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !isCanvasBlockElement(child.Data) {
+			inline.WriteString(c.renderInline(child))
+			continue
+		}
+		flushInline()
+		blocks = append(blocks, c.renderBlock(child)...)
+	}
+	flushInline()
+
+	return blocks
+}
+
+func isCanvasBlockElement(tag string) bool {
+	switch tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6", "p", "div", "ul", "ol", "table", "blockquote", "pre", "hr":
+		return true
+	}
+	return false
+}
+
+// renderBlock renders a single block element, which may expand into several blocks (e.g., a div wrapper)
+func (c *CanvasMarkdownConverter) renderBlock(n *html.Node) []string {
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := normalizeInline(c.renderInline(n))
+		if text == "" {
+			return nil
+		}
+		if c.plain {
+			return []string{text}
+		}
+		level := int(n.Data[1] - '0')
+		return []string{strings.Repeat("#", level) + " " + text}
+	case "p":
+		if hasClass(n, "embedded-file") {
+			return []string{c.renderEmbeddedFile(n)}
+		}
+		if text := normalizeInline(c.renderInline(n)); text != "" {
+			return []string{text}
+		}
+		return nil
+	case "div":
+		if style := getAttr(n, "data-section-style"); style != "" {
+			var blocks []string
+			for child := n.FirstChild; child != nil; child = child.NextSibling {
+				if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+					if list := c.renderList(child, style, 0); list != "" {
+						blocks = append(blocks, list)
+					}
+				}
+			}
+			return blocks
+		}
+		return c.renderBlocks(n)
+	case "ul":
+		if list := c.renderList(n, canvasListBullet, 0); list != "" {
+			return []string{list}
+		}
+		return nil
+	case "ol":
+		if list := c.renderList(n, canvasListNumbered, 0); list != "" {
+			return []string{list}
+		}
+		return nil
+	case "table":
+		if table := c.renderTable(n); table != "" {
+			return []string{table}
+		}
+		return nil
+	case "blockquote":
+		text := normalizeInline(c.renderInline(n))
+		if text == "" {
+			return nil
+		}
+		if c.plain {
+			return []string{text}
+		}
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			lines[i] = "> " + line
+		}
+		return []string{strings.Join(lines, "\n")}
+	case "pre":
+		code := strings.Trim(preformattedText(n), "\n")
+		if code == "" {
+			return nil
+		}
+		if c.plain {
+			return []string{code}
+		}
+		return []string{"```\n" + code + "\n```"}
+	case "hr":
+		if c.plain {
+			return nil
+		}
+		return []string{"---"}
+	}
+	return nil
+}
+
+// renderList renders a list. Slack nests sub-lists either inside <li> or as siblings of <li>, and both are indented.
+func (c *CanvasMarkdownConverter) renderList(n *html.Node, style string, depth int) string {
+	indent := strings.Repeat("    ", depth)
+	var lines []string
+	number := 0
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		switch child.Data {
+		case "ul", "ol":
+			if nested := c.renderList(child, style, depth+1); nested != "" {
+				lines = append(lines, nested)
+			}
+		case "li":
+			number++
+			var marker string
+			switch style {
+			case canvasListNumbered:
+				marker = fmt.Sprintf("%d. ", number)
+			case canvasListChecklist:
+				if hasClass(child, "checked") {
+					marker = "- [x] "
+				} else {
+					marker = "- [ ] "
+				}
+			default:
+				marker = "- "
+			}
+
+			var text strings.Builder
+			var nested []string
+			for grandchild := child.FirstChild; grandchild != nil; grandchild = grandchild.NextSibling {
+				if grandchild.Type == html.ElementNode && (grandchild.Data == "ul" || grandchild.Data == "ol") {
+					if list := c.renderList(grandchild, style, depth+1); list != "" {
+						nested = append(nested, list)
+					}
+					continue
+				}
+				text.WriteString(c.renderInline(grandchild))
+			}
+
+			item := strings.ReplaceAll(normalizeInline(text.String()), "\n", "\n"+indent+strings.Repeat(" ", len(marker)))
+			lines = append(lines, indent+marker+item)
+			lines = append(lines, nested...)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderTable renders a table as a GFM table, treating the first row as the header
+func (c *CanvasMarkdownConverter) renderTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.Data == "tr" {
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						text := strings.ReplaceAll(normalizeInline(c.renderInline(cell)), "\n", " ")
+						if !c.plain {
+							text = strings.ReplaceAll(text, "|", `\|`)
+						}
+						cells = append(cells, text)
+					}
+				}
+				rows = append(rows, cells)
+				continue
+			}
+			walk(child)
+		}
+	}
+	walk(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	for i := range rows {
+		for len(rows[i]) < columns {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	var lines []string
+	for i, row := range rows {
+		if c.plain {
+			// Padding cells are dropped since plain text has no column alignment to keep
+			for len(row) > 0 && row[len(row)-1] == "" {
+				row = row[:len(row)-1]
+			}
+			lines = append(lines, strings.Join(row, " | "))
+			continue
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderEmbeddedFile renders Slack's "File ID: ... File URL: ..." paragraph as a link
+func (c *CanvasMarkdownConverter) renderEmbeddedFile(n *html.Node) string {
+	text := normalizeInline(textContent(n))
+	matches := embeddedFilePattern.FindStringSubmatch(text)
+	if matches == nil {
+		return text
+	}
+	if c.plain {
+		return fmt.Sprintf("Embedded file %s: %s", matches[1], matches[2])
+	}
+	return fmt.Sprintf("[Embedded file %s](%s)", matches[1], matches[2])
+}
+
+// renderInline renders inline content. Whitespace is kept as-is and normalized by the caller.
+func (c *CanvasMarkdownConverter) renderInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return htmlWhitespacePattern.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.renderInline(child))
+	}
+	content := b.String()
+
+	if c.plain {
+		switch n.Data {
+		case "br":
+			return "\n"
+		case "a":
+			href := getAttr(n, "href")
+			text := strings.TrimSpace(content)
+			if href == "" || text == href {
+				return content
+			}
+			if text == "" {
+				return href
+			}
+			return wrapInline(content, "", " ("+href+")")
+		}
+		return content
+	}
+
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "b", "strong":
+		return wrapInline(content, "**", "**")
+	case "i", "em":
+		return wrapInline(content, "_", "_")
+	case "del", "s", "strike":
+		return wrapInline(content, "~~", "~~")
+	case "code":
+		return wrapInline(content, "`", "`")
+	case "a":
+		href := getAttr(n, "href")
+		if href == "" {
+			return content
+		}
+		text := strings.TrimSpace(content)
+		if text == "" || text == href {
+			return href
+		}
+		return wrapInline(content, "[", "]("+href+")")
+	}
+	return content
+}
+
+// wrapInline wraps text with prefix and suffix, keeping surrounding whitespace outside of them
+func wrapInline(text, prefix, suffix string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+	return leading + prefix + trimmed + suffix + trailing
+}
+
+// normalizeInline collapses whitespace within each line and trims blank lines at both ends.
+// Only <br> produces "\n" here, since source line breaks in text nodes were already collapsed into spaces.
+func normalizeInline(text string) string {
+	lines := strings.Split(text, "\n")
+	var result []string
+	for _, line := range lines {
+		result = append(result, strings.Join(strings.Fields(line), " "))
+	}
+	return strings.Trim(strings.Join(result, "\n"), "\n")
+}
+
+// preformattedText returns the text of a <pre> element, turning <br> into newlines
+func preformattedText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			switch {
+			case child.Type == html.TextNode:
+				b.WriteString(child.Data)
+			case child.Type == html.ElementNode && child.Data == "br":
+				b.WriteString("\n")
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// textContent returns the concatenated text of n and its descendants
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(getAttr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
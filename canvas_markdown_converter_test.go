@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// TestCanvasMarkdownConverter_Golden converts each testdata/canvas/*.html and compares the result
+// with the .md (markdown) and .txt (text) golden files next to it. Run with -update to regenerate them.
+func TestCanvasMarkdownConverter_Golden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "canvas", "*.html"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, inputs)
+
+	converters := []struct {
+		ext       string
+		converter *CanvasMarkdownConverter
+	}{
+		{".md", NewCanvasMarkdownConverter()},
+		{".txt", NewCanvasTextConverter()},
+	}
+
+	for _, input := range inputs {
+		html, err := os.ReadFile(input)
+		assert.NoError(t, err)
+
+		for _, c := range converters {
+			golden := strings.TrimSuffix(input, ".html") + c.ext
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				result, err := c.converter.Convert(string(html))
+				assert.NoError(t, err)
+
+				if *updateGolden {
+					assert.NoError(t, os.WriteFile(golden, []byte(result+"\n"), 0o644))
+				}
+
+				expected, err := os.ReadFile(golden)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), result+"\n")
+			})
+		}
+	}
+}
+
+func TestCanvasMarkdownConverter_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "collapses source whitespace but keeps br",
+			input:    "<p>line\n   one<br/>line two</p>",
+			expected: "line one\nline two",
+		},
+		{
+			name:     "plain ul and ol without section style",
+			input:    "<ul><li>a</li><li>b</li></ul><ol><li>one</li><li>two</li></ol>",
+			expected: "- a\n- b\n\n1. one\n2. two",
+		},
+		{
+			name:     "multi-line list item is aligned under the marker",
+			input:    "<div data-section-style='6'><ul><li>first<br/>continued</li></ul></div>",
+			expected: "1. first\n   continued",
+		},
+		{
+			name:     "embedded-file without the expected format keeps its text",
+			input:    "<p class='embedded-file'>Attached file</p>",
+			expected: "Attached file",
+		},
+	}
+
+	converter := NewCanvasMarkdownConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := converter.Convert(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
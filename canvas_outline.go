@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// canvasIntroSectionID identifies the content before the first heading
+const canvasIntroSectionID = "intro"
+
+// CanvasSection is a node of a canvas outline
+type CanvasSection struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Level int    `json:"level"`
+	// Size is the number of characters of the section, including its subsections, in the requested format
+	Size     int             `json:"size"`
+	Sections []CanvasSection `json:"sections,omitempty"`
+}
+
+// CanvasDocument is a canvas split into sections by its top-level headings
+type CanvasDocument struct {
+	nodes    []*html.Node
+	sections []canvasSectionRange
+}
+
+// canvasSectionRange is a heading and the top-level nodes it covers, up to the next heading of the same or higher level
+type canvasSectionRange struct {
+	id    string
+	title string
+	level int
+	start int
+	end   int
+}
+
+// ParseCanvasDocument parses canvas HTML and indexes its sections.
+// Section IDs come from the heading id attribute Slack assigns (e.g., "temp:C:AAA"), falling back to the heading position.
+func ParseCanvasDocument(htmlStr string) (*CanvasDocument, error) {
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &CanvasDocument{}
+	body := NewCanvasHTMLStripper().findBody(doc)
+	if body == nil {
+		return d, nil
+	}
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		d.nodes = append(d.nodes, c)
+	}
+
+	var headings []canvasSectionRange
+	for i, n := range d.nodes {
+		level := headingLevel(n)
+		if level == 0 {
+			continue
+		}
+		id := getAttr(n, "id")
+		if id == "" {
+			id = fmt.Sprintf("section-%d", len(headings)+1)
+		}
+		headings = append(headings, canvasSectionRange{
+			id:    id,
+			title: normalizeInline(textContent(n)),
+			level: level,
+			start: i,
+			end:   len(d.nodes),
+		})
+	}
+	for i := range headings {
+		for _, next := range headings[i+1:] {
+			if next.level <= headings[i].level {
+				headings[i].end = next.start
+				break
+			}
+		}
+	}
+
+	introEnd := len(d.nodes)
+	if len(headings) > 0 {
+		introEnd = headings[0].start
+	}
+	if hasCanvasContent(d.nodes[:introEnd]) {
+		d.sections = append(d.sections, canvasSectionRange{id: canvasIntroSectionID, start: 0, end: introEnd})
+	}
+	d.sections = append(d.sections, headings...)
+
+	return d, nil
+}
+
+// Outline returns the heading tree. sizeOf measures the size of each section's HTML in the requested format.
+func (d *CanvasDocument) Outline(sizeOf func(htmlStr string) (int, error)) ([]CanvasSection, error) {
+	var roots []CanvasSection
+	// stack holds the path of open sections as pointers into their parents' Sections slices
+	type openSection struct {
+		level    int
+		children *[]CanvasSection
+	}
+	stack := []openSection{{level: -1, children: &roots}}
+
+	for _, s := range d.sections {
+		size, err := sizeOf(d.renderRange(s.start, s.end))
+		if err != nil {
+			return nil, err
+		}
+
+		if s.level == 0 {
+			// The intro precedes every heading and never has subsections
+			roots = append(roots, CanvasSection{ID: s.id, Title: s.title, Size: size})
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].level >= s.level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].children
+		*parent = append(*parent, CanvasSection{
+			ID:    s.id,
+			Title: s.title,
+			Level: s.level,
+			Size:  size,
+		})
+		stack = append(stack, openSection{level: s.level, children: &(*parent)[len(*parent)-1].Sections})
+	}
+
+	return roots, nil
+}
+
+// SectionsHTML returns the HTML of the given sections, including their subsections, in document order.
+// Overlapping sections (e.g., a section and one of its subsections) are included once.
+func (d *CanvasDocument) SectionsHTML(ids []string) (string, error) {
+	included := make([]bool, len(d.nodes))
+	for _, id := range ids {
+		found := false
+		for _, s := range d.sections {
+			if s.id == id {
+				for i := s.start; i < s.end; i++ {
+					included[i] = true
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("section not found: '%s'. Use outline to list section IDs", id)
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, n := range d.nodes {
+		if included[i] {
+			if err := html.Render(&buf, n); err != nil {
+				return "", err
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+func (d *CanvasDocument) renderRange(start, end int) string {
+	var buf bytes.Buffer
+	for _, n := range d.nodes[start:end] {
+		// Rendering nodes parsed by html.Parse into a bytes.Buffer does not fail
+		_ = html.Render(&buf, n)
+	}
+	return buf.String()
+}
+
+// headingLevel returns 1-6 for h1-h6 elements and 0 otherwise
+func headingLevel(n *html.Node) int {
+	if n.Type != html.ElementNode || len(n.Data) != 2 || n.Data[0] != 'h' {
+		return 0
+	}
+	level := int(n.Data[1] - '0')
+	if level < 1 || level > 6 {
+		return 0
+	}
+	return level
+}
+
+// hasCanvasContent reports whether nodes contain anything other than whitespace
+func hasCanvasContent(nodes []*html.Node) bool {
+	for _, n := range nodes {
+		if n.Type == html.ElementNode || strings.TrimSpace(textContent(n)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// canvasContentSize returns the size of canvas HTML once converted to format, in characters
+func canvasContentSize(format string) func(string) (int, error) {
+	return func(htmlStr string) (int, error) {
+		content, err := convertCanvasHTML(htmlStr, format)
+		if err != nil {
+			return 0, err
+		}
+		return utf8.RuneCountInString(content), nil
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanvasDocument_Outline(t *testing.T) {
+	// sizeOf counts rendered HTML bytes so that the tests do not depend on a converter
+	sizeOf := func(htmlStr string) (int, error) { return len(htmlStr), nil }
+
+	t.Run("builds a nested heading tree", func(t *testing.T) {
+		doc, err := ParseCanvasDocument(`<h1 id="a">A</h1><h2 id="a1">A1</h2><p>x</p><h3 id="a1a">A1a</h3><h2 id="a2">A2</h2><h1 id="b">B</h1>`)
+		assert.NoError(t, err)
+
+		outline, err := doc.Outline(sizeOf)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []CanvasSection{
+			{ID: "a", Title: "A", Level: 1, Size: 84, Sections: []CanvasSection{
+				{ID: "a1", Title: "A1", Level: 2, Size: 48, Sections: []CanvasSection{
+					{ID: "a1a", Title: "A1a", Level: 3, Size: 21},
+				}},
+				{ID: "a2", Title: "A2", Level: 2, Size: 19},
+			}},
+			{ID: "b", Title: "B", Level: 1, Size: 17},
+		}, outline)
+	})
+
+	t.Run("adds intro for content before the first heading and numbers headings without id", func(t *testing.T) {
+		doc, err := ParseCanvasDocument(`<p>lead</p><h2>Only</h2><p>body</p>`)
+		assert.NoError(t, err)
+
+		outline, err := doc.Outline(sizeOf)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []CanvasSection{
+			{ID: "intro", Size: 11},
+			{ID: "section-1", Title: "Only", Level: 2, Size: 24},
+		}, outline)
+	})
+
+	t.Run("returns no sections for empty canvas", func(t *testing.T) {
+		doc, err := ParseCanvasDocument("")
+		assert.NoError(t, err)
+
+		outline, err := doc.Outline(sizeOf)
+		assert.NoError(t, err)
+		assert.Empty(t, outline)
+	})
+}
+
+func TestCanvasDocument_SectionsHTML(t *testing.T) {
+	doc, err := ParseCanvasDocument(`<p>lead</p><h1 id="a">A</h1><h2 id="a1">A1</h2><h1 id="b">B</h1>`)
+	assert.NoError(t, err)
+
+	t.Run("returns sections with subsections in document order", func(t *testing.T) {
+		html, err := doc.SectionsHTML([]string{"b", "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, `<h1 id="a">A</h1><h2 id="a1">A1</h2><h1 id="b">B</h1>`, html)
+	})
+
+	t.Run("includes overlapping sections once", func(t *testing.T) {
+		html, err := doc.SectionsHTML([]string{"a1", "a", "intro"})
+		assert.NoError(t, err)
+		assert.Equal(t, `<p>lead</p><h1 id="a">A</h1><h2 id="a1">A1</h2>`, html)
+	})
+
+	t.Run("returns error for unknown section", func(t *testing.T) {
+		_, err := doc.SectionsHTML([]string{"missing"})
+		assert.EqualError(t, err, "section not found: 'missing'. Use outline to list section IDs")
+	})
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// conversationsListLimit is the page size used when loading conversations.list
+const conversationsListLimit = 1000
+
+// ChannelFilter specifies conditions for FindChannels
+type ChannelFilter struct {
+	// Name matches channels whose name contains this substring (case-insensitive)
+	Name            string
+	IncludeArchived bool
+	MemberOnly      bool
+}
+
+// ChannelRepository manages channel information with session-based caching
+type ChannelRepository struct {
+	cache *sessionCache[[]slack.Channel]
+}
+
+// NewChannelRepository creates a new ChannelRepository
+func NewChannelRepository() *ChannelRepository {
+	return &ChannelRepository{
+		cache: newSessionCache[[]slack.Channel](),
+	}
+}
+
+// FindChannels returns public and private channels matching the filter
+func (r *ChannelRepository) FindChannels(
+	ctx context.Context,
+	client SlackClient,
+	filter ChannelFilter,
+) ([]slack.Channel, error) {
+	channels, err := r.getChannels(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return r.filterChannels(channels, filter), nil
+}
+
+// FindByID returns the channel with the given ID, or nil if it does not exist.
+// It answers from the session's conversations.list when already cached and otherwise asks conversations.info for the single channel,
+// so that looking up one ID never loads the whole workspace.
+func (r *ChannelRepository) FindByID(ctx context.Context, client SlackClient, channelID string) (*slack.Channel, error) {
+	if channels, ok := r.cache.get(SessionIDFromContext(ctx)); ok {
+		for i := range channels {
+			if channels[i].ID == channelID {
+				return &channels[i], nil
+			}
+		}
+	}
+
+	channel, err := client.GetConversationInfo(ctx, channelID)
+	if errors.Is(err, ErrChannelNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// FindByName returns the channel with the given name (case-insensitive), or nil if none matches
+func (r *ChannelRepository) FindByName(ctx context.Context, client SlackClient, name string) (*slack.Channel, error) {
+	channels, err := r.getChannels(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for i := range channels {
+		if strings.EqualFold(channels[i].Name, name) {
+			return &channels[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// getChannels returns the cached channels for the session, loading them from Slack on a miss
+func (r *ChannelRepository) getChannels(ctx context.Context, client SlackClient) ([]slack.Channel, error) {
+	return r.cache.getOrLoad(ctx, "channels", func() ([]slack.Channel, error) {
+		return r.fetchAllChannels(ctx, client)
+	})
+}
+
+// fetchAllChannels follows conversations.list cursors until every page is loaded
+func (r *ChannelRepository) fetchAllChannels(ctx context.Context, client SlackClient) ([]slack.Channel, error) {
+	var all []slack.Channel
+	cursor := ""
+	for {
+		channels, nextCursor, err := client.GetConversations(ctx, &slack.GetConversationsParameters{
+			Cursor: cursor,
+			Limit:  conversationsListLimit,
+			Types:  []string{"public_channel", "private_channel"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, channels...)
+
+		if nextCursor == "" {
+			return all, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (r *ChannelRepository) filterChannels(channels []slack.Channel, filter ChannelFilter) []slack.Channel {
+	name := strings.ToLower(filter.Name)
+
+	var matches []slack.Channel
+	for _, channel := range channels {
+		if !filter.IncludeArchived && channel.IsArchived {
+			continue
+		}
+		if filter.MemberOnly && !channel.IsMember {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(channel.Name), name) {
+			continue
+		}
+		matches = append(matches, channel)
+	}
+	return matches
+}
+
+func (r *ChannelRepository) Close() {
+	r.cache.close()
+}
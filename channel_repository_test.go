@@ -0,0 +1,169 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestChannel(id, name string, isArchived, isMember bool) slack.Channel {
+	channel := slack.Channel{IsMember: isMember}
+	channel.ID = id
+	channel.Name = name
+	channel.IsArchived = isArchived
+	return channel
+}
+
+func TestChannelRepository_FindChannels(t *testing.T) {
+	channels := []slack.Channel{
+		newTestChannel("C1111111", "general", false, true),
+		newTestChannel("C2222222", "team-dev", false, false),
+		newTestChannel("C3333333", "old-dev", true, true),
+	}
+
+	t.Run("loads all pages of conversations.list", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversations", t.Context(), &slack.GetConversationsParameters{
+			Limit: conversationsListLimit,
+			Types: []string{"public_channel", "private_channel"},
+		}).Return(channels[:1], "next-page", nil).Once()
+		mockClient.On("GetConversations", t.Context(), &slack.GetConversationsParameters{
+			Cursor: "next-page",
+			Limit:  conversationsListLimit,
+			Types:  []string{"public_channel", "private_channel"},
+		}).Return(channels[1:], "", nil).Once()
+
+		repo := NewChannelRepository()
+		t.Cleanup(repo.Close)
+
+		result, err := repo.FindChannels(t.Context(), mockClient, ChannelFilter{IncludeArchived: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, channels, result)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("filters by name, archived state and membership", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			filter   ChannelFilter
+			expected []string
+		}{
+			{"excludes archived by default", ChannelFilter{}, []string{"C1111111", "C2222222"}},
+			{"includes archived", ChannelFilter{IncludeArchived: true}, []string{"C1111111", "C2222222", "C3333333"}},
+			{"name substring is case-insensitive", ChannelFilter{Name: "DEV", IncludeArchived: true}, []string{"C2222222", "C3333333"}},
+			{"member only", ChannelFilter{MemberOnly: true}, []string{"C1111111"}},
+			{"no match", ChannelFilter{Name: "nothing"}, nil},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetConversations", t.Context(), mock.Anything).Return(channels, "", nil).Once()
+
+				repo := NewChannelRepository()
+				t.Cleanup(repo.Close)
+
+				result, err := repo.FindChannels(t.Context(), mockClient, tc.filter)
+				assert.NoError(t, err)
+
+				var ids []string
+				for _, channel := range result {
+					ids = append(ids, channel.ID)
+				}
+				assert.Equal(t, tc.expected, ids)
+			})
+		}
+	})
+
+	t.Run("uses cache per session and refreshes after ttl expiry", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		now := time.Now()
+
+		repo := NewChannelRepository()
+		repo.cache.now = func() time.Time { return now }
+		t.Cleanup(repo.Close)
+
+		ctx1 := WithSessionID(t.Context(), SessionID("session-1"))
+		ctx2 := WithSessionID(t.Context(), SessionID("session-2"))
+
+		mockClient.On("GetConversations", ctx1, mock.Anything).Return(channels[:1], "", nil).Once()
+		mockClient.On("GetConversations", ctx2, mock.Anything).Return(channels[1:2], "", nil).Once()
+
+		result1, err := repo.FindChannels(ctx1, mockClient, ChannelFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, result1, 1)
+		assert.Equal(t, "C1111111", result1[0].ID)
+
+		result2, err := repo.FindChannels(ctx2, mockClient, ChannelFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, result2, 1)
+		assert.Equal(t, "C2222222", result2[0].ID)
+
+		// Cached
+		result3, err := repo.FindChannels(ctx1, mockClient, ChannelFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, result1, result3)
+
+		// Refresh after TTL
+		mockClient.On("GetConversations", ctx1, mock.Anything).Return(channels[1:2], "", nil).Once()
+		now = now.Add(cacheTTL + time.Second)
+
+		result4, err := repo.FindChannels(ctx1, mockClient, ChannelFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, result4, 1)
+		assert.Equal(t, "C2222222", result4[0].ID)
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestChannelRepository_FindByID(t *testing.T) {
+	general := newTestChannel("C1111111", "general", false, true)
+
+	t.Run("uses conversations.info without listing channels", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", t.Context(), "C1111111").Return(&general, nil).Once()
+
+		repo := NewChannelRepository()
+		t.Cleanup(repo.Close)
+
+		result, err := repo.FindByID(t.Context(), mockClient, "C1111111")
+		assert.NoError(t, err)
+		assert.Equal(t, &general, result)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "GetConversations", mock.Anything, mock.Anything)
+	})
+
+	t.Run("answers from the cached channel list when loaded", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversations", t.Context(), mock.Anything).Return([]slack.Channel{general}, "", nil).Once()
+
+		repo := NewChannelRepository()
+		t.Cleanup(repo.Close)
+
+		_, err := repo.FindChannels(t.Context(), mockClient, ChannelFilter{})
+		assert.NoError(t, err)
+
+		result, err := repo.FindByID(t.Context(), mockClient, "C1111111")
+		assert.NoError(t, err)
+		assert.Equal(t, &general, result)
+		mockClient.AssertNotCalled(t, "GetConversationInfo", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns nil for unknown channels", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", t.Context(), "C9999999").Return(nil, ErrChannelNotFound).Once()
+
+		repo := NewChannelRepository()
+		t.Cleanup(repo.Close)
+
+		result, err := repo.FindByID(t.Context(), mockClient, "C9999999")
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
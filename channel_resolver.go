@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// channelIDPattern matches conversation IDs: C (public or private channel), G (legacy private channel or group DM) and D (direct message)
+var channelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{2,}$`)
+
+// parseChannelRef splits a user-supplied channel reference into an ID or a name.
+// It accepts a channel ID ("C1234567"), a name ("general") or a hashed name ("#general").
+func parseChannelRef(channel string) (id string, name string) {
+	channel = strings.TrimSpace(channel)
+	if channelIDPattern.MatchString(channel) {
+		return channel, ""
+	}
+	return "", strings.TrimPrefix(channel, "#")
+}
+
+// resolveChannelID resolves a channel reference into ChannelInfo with a guaranteed ID.
+// The name is looked up on a best-effort basis so that tools keep working without channels:read.
+func (h *Handler) resolveChannelID(ctx context.Context, client SlackClient, channel string) (*ChannelInfo, error) {
+	id, name := parseChannelRef(channel)
+	if id != "" {
+		info := &ChannelInfo{ID: id}
+		found, err := h.channelRepository.FindByID(ctx, client, id)
+		if err != nil {
+			slog.Debug("failed to look up channel name", "channelID", id, "error", err)
+		} else if found != nil {
+			info.Name = found.Name
+		}
+		return info, nil
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("channel is empty")
+	}
+
+	found, err := h.channelRepository.FindByName(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("channel not found: '%s'. Use list_channels to find the correct channel name or ID", name)
+	}
+	return &ChannelInfo{ID: found.ID, Name: found.Name}, nil
+}
+
+// resolveChannelName resolves a channel reference into a channel name usable in search modifiers.
+// Names are passed through as-is because Slack search resolves them itself.
+func (h *Handler) resolveChannelName(ctx context.Context, client SlackClient, channel string) (string, error) {
+	id, name := parseChannelRef(channel)
+	if id == "" {
+		return name, nil
+	}
+
+	found, err := h.channelRepository.FindByID(ctx, client, id)
+	if err != nil {
+		return "", err
+	}
+	if found == nil {
+		return "", fmt.Errorf("channel not found: '%s'. Use list_channels to find the correct channel name or ID", id)
+	}
+	return found.Name, nil
+}
+
+// historyScopeFor returns the OAuth scope needed to read the history of the given conversation
+func historyScopeFor(channelID string) string {
+	switch {
+	case strings.HasPrefix(channelID, "D"):
+		return "im:history"
+	case strings.HasPrefix(channelID, "G"):
+		// G IDs are used for both legacy private channels and group DMs
+		return "groups:history or mpim:history"
+	default:
+		// Newer private channels also have C IDs, so the ID alone does not tell which scope is missing
+		return "channels:history or groups:history"
+	}
+}
+
+// mapHistoryError converts a missing scope error into a message naming the scope the conversation type requires
+func mapHistoryError(err error, channelID string) error {
+	if errors.Is(err, ErrMissingScope) {
+		return fmt.Errorf("%w: reading conversation %s requires the %s scope. Please add it to your Slack app's User Token Scopes and reinstall the app", ErrMissingScope, channelID, historyScopeFor(channelID))
+	}
+	return err
+}
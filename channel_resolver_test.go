@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseChannelRef(t *testing.T) {
+	tests := []struct {
+		name         string
+		channel      string
+		expectedID   string
+		expectedName string
+	}{
+		{"public channel ID", "C1234567", "C1234567", ""},
+		{"private channel ID", "G1234567", "G1234567", ""},
+		{"direct message ID", "D1234567", "D1234567", ""},
+		{"channel name", "general", "", "general"},
+		{"hashed channel name", "#general", "", "general"},
+		{"name with surrounding spaces", "  #team-dev ", "", "team-dev"},
+		{"lowercase name that looks like ID", "c1234567", "", "c1234567"},
+		{"non-ASCII name", "チーム-dev", "", "チーム-dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, name := parseChannelRef(tt.channel)
+			assert.Equal(t, tt.expectedID, id)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
+func TestHandler_resolveChannelID(t *testing.T) {
+	channels := []slack.Channel{
+		newTestChannel("C1234567", "general", false, true),
+		newTestChannel("G2345678", "secret", false, true),
+	}
+
+	t.Run("resolves names via conversations.list", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			channel  string
+			expected *ChannelInfo
+		}{
+			{"name", "general", &ChannelInfo{ID: "C1234567", Name: "general"}},
+			{"hashed name", "#secret", &ChannelInfo{ID: "G2345678", Name: "secret"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetConversations", mock.Anything, mock.Anything).Return(channels, "", nil)
+
+				channelRepo := NewChannelRepository()
+				t.Cleanup(channelRepo.Close)
+				handler := &Handler{channelRepository: channelRepo}
+
+				info, err := handler.resolveChannelID(t.Context(), mockClient, tc.channel)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, info)
+			})
+		}
+	})
+
+	t.Run("looks up IDs with conversations.info without listing channels", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			channel  string
+			info     *slack.Channel
+			infoErr  error
+			expected *ChannelInfo
+		}{
+			{"ID", "C1234567", &channels[0], nil, &ChannelInfo{ID: "C1234567", Name: "general"}},
+			{"unknown ID keeps ID only", "D9999999", nil, fmt.Errorf("%w: channel_not_found", ErrChannelNotFound), &ChannelInfo{ID: "D9999999"}},
+			{"lookup failure keeps ID only", "C1234567", nil, errors.New("missing required scope: missing_scope"), &ChannelInfo{ID: "C1234567"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetConversationInfo", mock.Anything, tc.channel).Return(tc.info, tc.infoErr).Once()
+
+				channelRepo := NewChannelRepository()
+				t.Cleanup(channelRepo.Close)
+				handler := &Handler{channelRepository: channelRepo}
+
+				info, err := handler.resolveChannelID(t.Context(), mockClient, tc.channel)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, info)
+				mockClient.AssertExpectations(t)
+				mockClient.AssertNotCalled(t, "GetConversations", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("returns error for unknown name", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return(channels, "", nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{channelRepository: channelRepo}
+
+		_, err := handler.resolveChannelID(t.Context(), mockClient, "random")
+		assert.EqualError(t, err, "channel not found: 'random'. Use list_channels to find the correct channel name or ID")
+	})
+}
+
+func TestHandler_resolveChannelName(t *testing.T) {
+	channels := []slack.Channel{
+		newTestChannel("C1234567", "general", false, true),
+	}
+
+	t.Run("passes names through without API call", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		handler := &Handler{}
+
+		name, err := handler.resolveChannelName(t.Context(), mockClient, "#general")
+		assert.NoError(t, err)
+		assert.Equal(t, "general", name)
+		mockClient.AssertNotCalled(t, "GetConversations", mock.Anything, mock.Anything)
+	})
+
+	t.Run("resolves ID to name", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&channels[0], nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{channelRepository: channelRepo}
+
+		name, err := handler.resolveChannelName(t.Context(), mockClient, "C1234567")
+		assert.NoError(t, err)
+		assert.Equal(t, "general", name)
+	})
+
+	t.Run("returns error for unknown ID", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", mock.Anything, "C9999999").Return(nil, fmt.Errorf("%w: channel_not_found", ErrChannelNotFound))
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{channelRepository: channelRepo}
+
+		_, err := handler.resolveChannelName(t.Context(), mockClient, "C9999999")
+		assert.EqualError(t, err, "channel not found: 'C9999999'. Use list_channels to find the correct channel name or ID")
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// defaultMaxConcurrency is the number of Slack API calls a single tool call may run in parallel
+const defaultMaxConcurrency = 8
+
+// LoadMaxConcurrencyFromEnv reads SLACK_MAX_CONCURRENCY, falling back to defaultMaxConcurrency for unset or invalid values
+func LoadMaxConcurrencyFromEnv() int {
+	v := os.Getenv("SLACK_MAX_CONCURRENCY")
+	if v == "" {
+		return defaultMaxConcurrency
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		slog.Warn("ignoring invalid SLACK_MAX_CONCURRENCY", "value", v)
+		return defaultMaxConcurrency
+	}
+	return n
+}
+
+// forEachConcurrently calls fn for each index in [0, n) using at most limit goroutines and waits for all of them.
+// fn must write its result to a slot owned by its index so that callers can keep the input order.
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range n {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,103 @@
+// Package document extracts plain text from PDF and Office Open XML (DOCX, XLSX, PPTX) files
+// using only the standard library.
+package document
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Format is a supported document format
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatDOCX Format = "docx"
+	FormatXLSX Format = "xlsx"
+	FormatPPTX Format = "pptx"
+)
+
+// mimetypeFormats maps document mimetypes to their format, for files whose Slack filetype is missing
+var mimetypeFormats = map[string]Format{
+	"application/pdf": FormatPDF,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   FormatDOCX,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         FormatXLSX,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": FormatPPTX,
+}
+
+// DetectFormat returns the format of a file from its Slack filetype and mimetype.
+// ok is false when the file is not a supported document.
+func DetectFormat(filetype, mimetype string) (Format, bool) {
+	switch Format(filetype) {
+	case FormatPDF, FormatDOCX, FormatXLSX, FormatPPTX:
+		return Format(filetype), true
+	}
+	mediaType, _, _ := strings.Cut(mimetype, ";")
+	format, ok := mimetypeFormats[strings.TrimSpace(mediaType)]
+	return format, ok
+}
+
+// Part is a unit of a document: a page of a PDF, a sheet of an XLSX or a slide of a PPTX.
+// A DOCX has no reliable page boundaries and is returned as a single part.
+type Part struct {
+	// Number is the 1-based position of the part in the document
+	Number int
+	// Name is the sheet name for XLSX and empty otherwise
+	Name string
+	Text string
+}
+
+// ErrEncrypted is returned for password-protected documents
+var ErrEncrypted = errors.New("encrypted documents are not supported")
+
+// PartFilter reports whether the text of a part is needed. It receives the part with Number and Name set.
+// Parts it rejects are still returned, with empty Text, so that callers can see every part of the document.
+// A nil PartFilter selects every part.
+type PartFilter func(Part) bool
+
+func (f PartFilter) includes(part Part) bool {
+	return f == nil || f(part)
+}
+
+// Extract extracts the text of the parts of the document selected by include.
+// Malformed documents return an error rather than crashing the caller, even if a parser panics on them.
+func Extract(data []byte, format Format, include PartFilter) (parts []Part, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			parts, err = nil, fmt.Errorf("failed to parse %s document: %v", format, r)
+		}
+	}()
+
+	switch format {
+	case FormatPDF:
+		return ExtractPDF(data, include)
+	case FormatDOCX:
+		return ExtractDOCX(data)
+	case FormatXLSX:
+		return ExtractXLSX(data, include)
+	case FormatPPTX:
+		return ExtractPPTX(data, include)
+	}
+	return nil, fmt.Errorf("unsupported document format: %s", format)
+}
+
+// normalizeText trims trailing spaces on each line and collapses runs of blank lines into one
+func normalizeText(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		result = append(result, line)
+	}
+	return strings.Trim(strings.Join(result, "\n"), "\n")
+}
@@ -0,0 +1,32 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filetype string
+		mimetype string
+		expected Format
+		ok       bool
+	}{
+		{"pdf filetype", "pdf", "application/pdf", FormatPDF, true},
+		{"docx filetype", "docx", "", FormatDOCX, true},
+		{"xlsx by mimetype", "binary", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", FormatXLSX, true},
+		{"pptx by mimetype with parameters", "", "application/vnd.openxmlformats-officedocument.presentationml.presentation; charset=binary", FormatPPTX, true},
+		{"legacy doc", "doc", "application/msword", "", false},
+		{"image", "png", "image/png", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, ok := DetectFormat(tt.filetype, tt.mimetype)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// maxZipEntryBytes caps the decompressed size of a single OOXML part to guard against zip bombs
+	maxZipEntryBytes = 100 << 20
+	// maxZipTotalBytes caps the decompressed size of all parts read from one archive,
+	// so that many parts just under maxZipEntryBytes cannot add up to gigabytes
+	maxZipTotalBytes = 200 << 20
+)
+
+// oleSignature starts OLE compound files. Password-protected OOXML files are stored in this container instead of zip.
+var oleSignature = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+// relationshipsNamespace is the namespace of r:id attributes that point into a .rels file
+const relationshipsNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+// ExtractDOCX extracts the text of the main document body. Tables are rendered with tab-separated cells.
+func ExtractDOCX(data []byte) ([]Part, error) {
+	zr, err := openZip(data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readZipFile(zr, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	text, err := extractParagraphText(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse word/document.xml: %w", err)
+	}
+	return []Part{{Number: 1, Text: text}}, nil
+}
+
+// ExtractPPTX extracts the text of each slide selected by include, in presentation order
+func ExtractPPTX(data []byte, include PartFilter) ([]Part, error) {
+	zr, err := openZip(data)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation, err := readZipFile(zr, "ppt/presentation.xml")
+	if err != nil {
+		return nil, err
+	}
+	rels, err := readRelationships(zr, "ppt/presentation.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var slideIDs []string
+	decoder := xml.NewDecoder(bytes.NewReader(presentation))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ppt/presentation.xml: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "sldId" {
+			slideIDs = append(slideIDs, relationshipID(start))
+		}
+	}
+
+	parts := make([]Part, 0, len(slideIDs))
+	for i, id := range slideIDs {
+		part := Part{Number: i + 1}
+		if !include.includes(part) {
+			parts = append(parts, part)
+			continue
+		}
+		target, ok := rels[id]
+		if !ok {
+			return nil, fmt.Errorf("slide %d has no relationship %q", i+1, id)
+		}
+		slide, err := readZipFile(zr, target)
+		if err != nil {
+			return nil, err
+		}
+		text, err := extractParagraphText(slide)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", target, err)
+		}
+		part.Text = text
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// extractParagraphText extracts text from WordprocessingML or DrawingML, where paragraphs (p) contain text runs (t).
+// Paragraphs inside table cells are joined with spaces so that each table row stays on one line.
+func extractParagraphText(data []byte) (string, error) {
+	var b strings.Builder
+	inText := false
+	cellDepth := 0
+	// Tab stop definitions in paragraph properties (w:tabs, a:tabLst) also contain tab elements
+	inTabStops := false
+	// cellParagraphEnded defers the space between paragraphs in a cell so that none is left before the next cell
+	cellParagraphEnded := false
+
+	write := func(s string) {
+		if cellParagraphEnded {
+			b.WriteString(" ")
+			cellParagraphEnded = false
+		}
+		b.WriteString(s)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tabs", "tabLst":
+				inTabStops = true
+			case "tab":
+				if !inTabStops {
+					write("\t")
+				}
+			case "br", "cr":
+				write("\n")
+			case "tc":
+				cellDepth++
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "tabs", "tabLst":
+				inTabStops = false
+			case "p":
+				if cellDepth > 0 {
+					cellParagraphEnded = b.Len() > 0
+				} else {
+					b.WriteString("\n")
+				}
+			case "tc":
+				cellDepth--
+				cellParagraphEnded = false
+				b.WriteString("\t")
+			case "tr":
+				b.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				write(string(t))
+			}
+		}
+	}
+
+	// Rows end with the separator of their last cell, which normalizeText trims
+	return normalizeText(b.String()), nil
+}
+
+// zipArchive is an OOXML archive that tracks how many decompressed bytes are left to read
+type zipArchive struct {
+	*zip.Reader
+	remaining int64
+}
+
+func openZip(data []byte) (*zipArchive, error) {
+	if bytes.HasPrefix(data, oleSignature) {
+		return nil, ErrEncrypted
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document archive: %w", err)
+	}
+	return &zipArchive{Reader: zr, remaining: maxZipTotalBytes}, nil
+}
+
+func findZipFile(zr *zipArchive, name string) *zip.File {
+	for _, f := range zr.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func readZipFile(zr *zipArchive, name string) ([]byte, error) {
+	f := findZipFile(zr, name)
+	if f == nil {
+		return nil, fmt.Errorf("%s not found in document archive", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, min(maxZipEntryBytes, zr.remaining)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) > maxZipEntryBytes {
+		return nil, fmt.Errorf("%s exceeds %d bytes when decompressed", name, maxZipEntryBytes)
+	}
+	if int64(len(data)) > zr.remaining {
+		return nil, fmt.Errorf("document archive exceeds %d bytes when decompressed", maxZipTotalBytes)
+	}
+	zr.remaining -= int64(len(data))
+	return data, nil
+}
+
+// readRelationships reads the .rels file of the given part and returns the targets keyed by relationship ID.
+// Targets are resolved to archive paths.
+func readRelationships(zr *zipArchive, partName string) (map[string]string, error) {
+	dir, file := path.Split(partName)
+	relsName := dir + "_rels/" + file + ".rels"
+	data, err := readZipFile(zr, relsName)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", relsName, err)
+	}
+
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join(dir, rel.Target)
+		}
+	}
+	return targets, nil
+}
+
+// relationshipID returns the r:id attribute of an element
+func relationshipID(start xml.StartElement) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "id" && attr.Name.Space == relationshipsNamespace {
+			return attr.Value
+		}
+	}
+	return ""
+}
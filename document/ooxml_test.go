@@ -0,0 +1,155 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildZip creates an archive with the given files in order
+func buildZip(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for _, f := range files {
+		w, err := zw.Create(f[0])
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(f[1]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return b.Bytes()
+}
+
+func TestExtractDOCX(t *testing.T) {
+	t.Run("extracts paragraphs, tabs, breaks and tables", func(t *testing.T) {
+		data := buildZip(t, [2]string{"word/document.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:tabs><w:tab w:val="left" w:pos="720"/></w:tabs></w:pPr><w:r><w:t>Title</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Name:</w:t><w:tab/><w:t>仕様書</w:t><w:br/><w:t>second line</w:t></w:r><w:del><w:r><w:delText>removed</w:delText></w:r></w:del></w:p>
+<w:tbl>
+<w:tr><w:tc><w:p><w:r><w:t>A1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>B1</w:t></w:r></w:p><w:p><w:r><w:t>more</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>A2</w:t></w:r></w:p></w:tc><w:tc><w:p/></w:tc></w:tr>
+</w:tbl>
+<w:p><w:r><w:t>After table</w:t></w:r></w:p>
+</w:body></w:document>`})
+
+		parts, err := ExtractDOCX(data)
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{{Number: 1, Text: "Title\nName:\t仕様書\nsecond line\nA1\tB1 more\nA2\nAfter table"}}, parts)
+	})
+
+	t.Run("returns ErrEncrypted for password-protected files", func(t *testing.T) {
+		_, err := ExtractDOCX(append([]byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}, make([]byte, 512)...))
+		assert.ErrorIs(t, err, ErrEncrypted)
+	})
+
+	t.Run("returns error when the document body is missing", func(t *testing.T) {
+		_, err := ExtractDOCX(buildZip(t, [2]string{"word/other.xml", "<x/>"}))
+		assert.EqualError(t, err, "word/document.xml not found in document archive")
+	})
+}
+
+func TestExtractXLSX(t *testing.T) {
+	data := buildZip(t,
+		[2]string{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Summary" sheetId="2" r:id="rId2"/><sheet name="データ" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		[2]string{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`},
+		[2]string{"xl/sharedStrings.xml", `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>名前</t></si><si><r><t>山田</t></r><r><t>太郎</t></r><rPh sb="0" eb="2"><t>ヤマダ</t></rPh></si></sst>`},
+		[2]string{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts><numFmt numFmtId="164" formatCode="yyyy/mm/dd hh:mm"/><numFmt numFmtId="165" formatCode="&quot;day&quot; 0"/></numFmts>
+<cellStyleXfs><xf numFmtId="14"/></cellStyleXfs>
+<cellXfs><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/><xf numFmtId="165"/></cellXfs></styleSheet>`},
+		[2]string{"xl/worksheets/sheet1.xml", `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><t>備考</t></is></c></row>
+<row r="2"><c r="A2" t="s"><v>1</v></c><c r="B2" s="1"><v>45292</v></c><c r="C2" s="2"><v>45292.5</v></c><c r="D2" s="3"><v>7</v></c></row>
+<row r="3"><c r="A3"/></row>
+<row r="4"><c r="A4" t="b"><v>1</v></c><c r="B4" t="str"><f>A1</f><v>line1
+line2</v></c><c r="C4" t="e"><v>#DIV/0!</v></c></row>
+</sheetData></worksheet>`},
+		[2]string{"xl/worksheets/sheet2.xml", `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row><c><v>1</v></c><c><v>2.5</v></c></row></sheetData></worksheet>`},
+	)
+
+	t.Run("extracts every sheet", func(t *testing.T) {
+		parts, err := ExtractXLSX(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{
+			{Number: 1, Name: "Summary", Text: "1\t2.5"},
+			{Number: 2, Name: "データ", Text: "名前\t\t備考\n山田太郎\t2024-01-01\t2024-01-01 12:00:00\t7\nTRUE\tline1 line2\t#DIV/0!"},
+		}, parts)
+	})
+
+	t.Run("extracts only the sheets selected by the filter", func(t *testing.T) {
+		parts, err := ExtractXLSX(data, func(part Part) bool { return part.Name == "Summary" })
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{
+			{Number: 1, Name: "Summary", Text: "1\t2.5"},
+			{Number: 2, Name: "データ"},
+		}, parts)
+	})
+}
+
+func TestIsDateFormatCode(t *testing.T) {
+	assert.True(t, isDateFormatCode("yyyy/mm/dd"))
+	assert.True(t, isDateFormatCode("[$-411]ggge\"年\"m\"月\"d\"日\""))
+	assert.True(t, isDateFormatCode("h:mm AM/PM"))
+	assert.False(t, isDateFormatCode("#,##0.00"))
+	assert.False(t, isDateFormatCode("\"days\" 0"))
+	assert.False(t, isDateFormatCode("[Red]0.0"))
+}
+
+func TestExtractPPTX(t *testing.T) {
+	data := buildZip(t,
+		[2]string{"ppt/presentation.xml", `<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<p:sldIdLst><p:sldId id="256" r:id="rId3"/><p:sldId id="257" r:id="rId2"/></p:sldIdLst></p:presentation>`},
+		[2]string{"ppt/_rels/presentation.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Target="slides/slide1.xml"/><Relationship Id="rId3" Target="slides/slide2.xml"/></Relationships>`},
+		[2]string{"ppt/slides/slide1.xml", `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Second slide</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`},
+		[2]string{"ppt/slides/slide2.xml", `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Agenda</a:t></a:r></a:p><a:p><a:r><a:t>Item</a:t></a:r><a:br/><a:r><a:t>continued</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`},
+	)
+
+	parts, err := ExtractPPTX(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{
+		{Number: 1, Text: "Agenda\nItem\ncontinued"},
+		{Number: 2, Text: "Second slide"},
+	}, parts)
+}
+
+func TestReadZipFile(t *testing.T) {
+	data := buildZip(t,
+		[2]string{"a.xml", strings.Repeat("a", 60)},
+		[2]string{"b.xml", strings.Repeat("b", 60)},
+	)
+
+	t.Run("reads parts within the total budget", func(t *testing.T) {
+		zr, err := openZip(data)
+		assert.NoError(t, err)
+		zr.remaining = 120
+
+		for _, name := range []string{"a.xml", "b.xml"} {
+			_, err := readZipFile(zr, name)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int64(0), zr.remaining)
+	})
+
+	t.Run("returns error when parts together exceed the total budget", func(t *testing.T) {
+		zr, err := openZip(data)
+		assert.NoError(t, err)
+		zr.remaining = 100
+
+		_, err = readZipFile(zr, "a.xml")
+		assert.NoError(t, err)
+		_, err = readZipFile(zr, "b.xml")
+		assert.EqualError(t, err, "document archive exceeds 209715200 bytes when decompressed")
+	})
+}
@@ -0,0 +1,300 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// objectHeaderPattern matches "12 0 obj" at the start of an indirect object
+var objectHeaderPattern = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// maxReferenceDepth bounds chains of indirect references, which are cyclic in broken files
+const maxReferenceDepth = 32
+
+// pdfFile holds the objects of a PDF. Objects are found by scanning the file rather than by reading the
+// cross-reference table, which tolerates the broken offsets common in files produced by simple tools.
+type pdfFile struct {
+	objects  map[int]any
+	trailers []pdfDict
+}
+
+// pdfPage is a page with its resources, which may be inherited from ancestor page tree nodes
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// ExtractPDF extracts the text layer of each page selected by include. Scanned pages without a text layer return empty text.
+// Content streams of pages that are not selected are not decoded.
+func ExtractPDF(data []byte, include PartFilter) ([]Part, error) {
+	f, err := parsePDFFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.pages()
+	if len(pages) == 0 {
+		return nil, errors.New("no pages found in PDF")
+	}
+
+	parts := make([]Part, len(pages))
+	for i, page := range pages {
+		parts[i] = Part{Number: i + 1}
+		if !include.includes(parts[i]) {
+			continue
+		}
+		e := newTextExtractor(f)
+		e.run(f.pageContents(page), page.resources, 0)
+		parts[i].Text = normalizeText(e.out.String())
+	}
+	return parts, nil
+}
+
+func parsePDFFile(data []byte) (*pdfFile, error) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, errors.New("not a PDF file")
+	}
+
+	f := &pdfFile{objects: map[int]any{}}
+
+	// Later definitions of the same object number come from incremental updates and win
+	pos := 0
+	for pos < len(data) {
+		loc := objectHeaderPattern.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		l := &pdfLexer{data: data, pos: pos + loc[1]}
+		obj, err := l.parseObject()
+		if err != nil {
+			pos += loc[1]
+			continue
+		}
+
+		afterObject := l.pos
+		if token, err := l.next(); err == nil && token == pdfKeyword("stream") {
+			if dict, ok := obj.(pdfDict); ok {
+				raw, end := readStreamData(data, l.pos, dict)
+				obj = &pdfStream{dict: dict, raw: raw}
+				afterObject = end
+			}
+		}
+		f.objects[num] = obj
+		// Truncated objects and streams can leave the position past the end of the data
+		pos = min(afterObject, len(data))
+	}
+
+	for i := 0; ; {
+		index := bytes.Index(data[i:], []byte("trailer"))
+		if index < 0 {
+			break
+		}
+		l := &pdfLexer{data: data, pos: i + index + len("trailer")}
+		if obj, err := l.parseObject(); err == nil {
+			if dict, ok := obj.(pdfDict); ok {
+				f.trailers = append(f.trailers, dict)
+			}
+		}
+		i += index + len("trailer")
+	}
+
+	// Cross-reference streams carry the trailer entries in PDF 1.5 and later, and object streams hold compressed objects
+	nums := make([]int, 0, len(f.objects))
+	for num := range f.objects {
+		nums = append(nums, num)
+	}
+	slices.Sort(nums)
+	for _, num := range nums {
+		stream, ok := f.objects[num].(*pdfStream)
+		if !ok {
+			continue
+		}
+		switch stream.dict["Type"] {
+		case pdfName("XRef"):
+			f.trailers = append(f.trailers, stream.dict)
+		case pdfName("ObjStm"):
+			f.expandObjectStream(stream)
+		}
+	}
+
+	for _, trailer := range f.trailers {
+		if _, ok := trailer["Encrypt"]; ok {
+			return nil, ErrEncrypted
+		}
+	}
+
+	return f, nil
+}
+
+// readStreamData returns the raw bytes of a stream starting right after the "stream" keyword and the position after "endstream".
+// A direct /Length is trusted when "endstream" follows it. Otherwise the data runs up to the next "endstream".
+func readStreamData(data []byte, pos int, dict pdfDict) ([]byte, int) {
+	if pos >= len(data) {
+		return nil, len(data)
+	}
+	if bytes.HasPrefix(data[pos:], []byte("\r\n")) {
+		pos += 2
+	} else if pos < len(data) && (data[pos] == '\n' || data[pos] == '\r') {
+		pos++
+	}
+
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && pos+int(length) <= len(data) {
+		end := pos + int(length)
+		rest := bytes.TrimLeft(data[end:min(len(data), end+32)], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return data[pos:end], end + bytes.Index(data[end:], []byte("endstream")) + len("endstream")
+		}
+	}
+
+	index := bytes.Index(data[pos:], []byte("endstream"))
+	if index < 0 {
+		return data[pos:], len(data)
+	}
+	raw := bytes.TrimSuffix(data[pos:pos+index], []byte("\n"))
+	raw = bytes.TrimSuffix(raw, []byte("\r"))
+	return raw, pos + index + len("endstream")
+}
+
+// expandObjectStream registers the objects compressed in an object stream, unless they were defined directly
+func (f *pdfFile) expandObjectStream(stream *pdfStream) {
+	data, err := decodeStream(stream, f.resolve)
+	if err != nil {
+		return
+	}
+	n, _ := f.resolve(stream.dict["N"]).(float64)
+	first, _ := f.resolve(stream.dict["First"]).(float64)
+
+	header := &pdfLexer{data: data}
+	for i := 0; i < int(n); i++ {
+		numToken, err1 := header.next()
+		offsetToken, err2 := header.next()
+		if err1 != nil || err2 != nil {
+			return
+		}
+		num, ok1 := numToken.(float64)
+		offset, ok2 := offsetToken.(float64)
+		if !ok1 || !ok2 {
+			return
+		}
+		if _, exists := f.objects[int(num)]; exists {
+			continue
+		}
+		start := int(first) + int(offset)
+		if start < 0 || start >= len(data) {
+			continue
+		}
+		if obj, err := (&pdfLexer{data: data, pos: start}).parseObject(); err == nil {
+			f.objects[int(num)] = obj
+		}
+	}
+}
+
+// resolve follows indirect references. Missing objects resolve to nil.
+func (f *pdfFile) resolve(v any) any {
+	for range maxReferenceDepth {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = f.objects[ref.num]
+	}
+	return nil
+}
+
+// dict resolves v into a dictionary, taking the dictionary of a stream
+func (f *pdfFile) dict(v any) pdfDict {
+	switch t := f.resolve(v).(type) {
+	case pdfDict:
+		return t
+	case *pdfStream:
+		return t.dict
+	}
+	return nil
+}
+
+// pages returns the pages in document order by walking the page tree from the catalog.
+// Files without a usable page tree fall back to every page object in object number order.
+func (f *pdfFile) pages() []pdfPage {
+	var pages []pdfPage
+	visited := map[any]bool{}
+
+	var walk func(node any, resources pdfDict)
+	walk = func(node any, resources pdfDict) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict := f.dict(node)
+		if dict == nil {
+			return
+		}
+		if r := f.dict(dict["Resources"]); r != nil {
+			resources = r
+		}
+		if kids, ok := f.resolve(dict["Kids"]).(pdfArray); ok {
+			for _, kid := range kids {
+				walk(kid, resources)
+			}
+			return
+		}
+		if dict["Type"] == pdfName("Page") || dict["Contents"] != nil {
+			pages = append(pages, pdfPage{dict: dict, resources: resources})
+		}
+	}
+
+	// The last trailer belongs to the latest incremental update
+	for i := len(f.trailers) - 1; i >= 0; i-- {
+		if catalog := f.dict(f.trailers[i]["Root"]); catalog != nil {
+			walk(catalog["Pages"], nil)
+			break
+		}
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	nums := make([]int, 0, len(f.objects))
+	for num := range f.objects {
+		nums = append(nums, num)
+	}
+	slices.Sort(nums)
+	for _, num := range nums {
+		if dict, ok := f.objects[num].(pdfDict); ok && dict["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: dict, resources: f.dict(dict["Resources"])})
+		}
+	}
+	return pages
+}
+
+// pageContents returns the decoded content streams of a page joined together. Undecodable streams are skipped.
+func (f *pdfFile) pageContents(page pdfPage) []byte {
+	var streams []any
+	switch c := f.resolve(page.dict["Contents"]).(type) {
+	case *pdfStream:
+		streams = []any{c}
+	case pdfArray:
+		streams = c
+	}
+
+	var contents []byte
+	for _, s := range streams {
+		stream, ok := f.resolve(s).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := decodeStream(stream, f.resolve)
+		if err != nil {
+			continue
+		}
+		// Streams may split in the middle of an operator's operands, but never within a token
+		contents = append(contents, data...)
+		contents = append(contents, '\n')
+	}
+	return contents
+}
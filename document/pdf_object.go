@@ -0,0 +1,365 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PDF object model. Dictionaries, arrays, names, strings, numbers, booleans, null,
+// indirect references and streams are represented by the types below.
+type (
+	pdfName    string
+	pdfString  []byte
+	pdfKeyword string
+	pdfArray   []any
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		raw  []byte
+	}
+)
+
+// maxStreamBytes caps the decoded size of a single stream to guard against decompression bombs
+const maxStreamBytes = 100 << 20
+
+// pdfLexer tokenizes PDF syntax used both in the file body and in content streams
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+// errEndOfData is returned by the lexer once the input is exhausted
+var errEndOfData = errors.New("unexpected end of data")
+
+// delimiter tokens returned as keywords so that the parser can recognize them
+const (
+	tokenArrayStart pdfKeyword = "["
+	tokenArrayEnd   pdfKeyword = "]"
+	tokenDictStart  pdfKeyword = "<<"
+	tokenDictEnd    pdfKeyword = ">>"
+)
+
+func isPDFWhitespace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) skipWhitespace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFWhitespace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next token: a number (float64), pdfName, pdfString or pdfKeyword
+func (l *pdfLexer) next() (any, error) {
+	l.skipWhitespace()
+	if l.pos >= len(l.data) {
+		return nil, errEndOfData
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		l.pos++
+		return l.readName(), nil
+	case c == '(':
+		l.pos++
+		return l.readLiteralString(), nil
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return tokenDictStart, nil
+		}
+		l.pos++
+		return l.readHexString(), nil
+	case c == '>':
+		l.pos++
+		if l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+			return tokenDictEnd, nil
+		}
+		return pdfKeyword(">"), nil
+	case c == '[' || c == ']' || c == '{' || c == '}' || c == ')':
+		l.pos++
+		return pdfKeyword(l.data[l.pos-1 : l.pos]), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		if n, err := strconv.ParseFloat(word, 64); err == nil {
+			return n, nil
+		}
+	}
+	return pdfKeyword(word), nil
+}
+
+func (l *pdfLexer) readName() pdfName {
+	var b []byte
+	for l.pos < len(l.data) && !isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if decoded, err := hex.DecodeString(string(l.data[l.pos+1 : l.pos+3])); err == nil {
+				b = append(b, decoded[0])
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return pdfName(b)
+}
+
+func (l *pdfLexer) readLiteralString() pdfString {
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return b
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// A backslash at the end of a line continues the string on the next line
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					n := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						n = n*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(n)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func (l *pdfLexer) readHexString() pdfString {
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isPDFWhitespace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	// An unterminated string runs to the end of the data
+	if l.pos < len(l.data) {
+		l.pos++
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	decoded, _ := hex.DecodeString(string(digits))
+	return decoded
+}
+
+// parseObject parses a complete object, combining "num gen R" into a pdfRef.
+// Keywords other than delimiters (e.g., content stream operators) are returned as pdfKeyword.
+func (l *pdfLexer) parseObject() (any, error) {
+	token, err := l.next()
+	if err != nil {
+		return nil, err
+	}
+	return l.parseFrom(token, 0)
+}
+
+// maxNestingDepth bounds arrays and dictionaries nested inside each other
+const maxNestingDepth = 64
+
+func (l *pdfLexer) parseFrom(token any, depth int) (any, error) {
+	if depth > maxNestingDepth {
+		return nil, errors.New("objects nested too deeply")
+	}
+
+	switch t := token.(type) {
+	case float64:
+		// Look ahead for "gen R" to form an indirect reference
+		saved := l.pos
+		if gen, err := l.next(); err == nil {
+			if g, ok := gen.(float64); ok {
+				if r, err := l.next(); err == nil && r == pdfKeyword("R") {
+					return pdfRef{num: int(t), gen: int(g)}, nil
+				}
+			}
+		}
+		l.pos = saved
+		return t, nil
+	case pdfKeyword:
+		switch t {
+		case tokenArrayStart:
+			var arr pdfArray
+			for {
+				item, err := l.next()
+				if err != nil {
+					return arr, err
+				}
+				if item == tokenArrayEnd {
+					return arr, nil
+				}
+				obj, err := l.parseFrom(item, depth+1)
+				if err != nil {
+					return arr, err
+				}
+				arr = append(arr, obj)
+			}
+		case tokenDictStart:
+			dict := pdfDict{}
+			for {
+				key, err := l.next()
+				if err != nil {
+					return dict, err
+				}
+				if key == tokenDictEnd {
+					return dict, nil
+				}
+				name, ok := key.(pdfName)
+				if !ok {
+					// Skip malformed entries instead of giving up on the whole dictionary
+					continue
+				}
+				valueToken, err := l.next()
+				if err != nil {
+					return dict, err
+				}
+				value, err := l.parseFrom(valueToken, depth+1)
+				if err != nil {
+					return dict, err
+				}
+				dict[name] = value
+			}
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	return token, nil
+}
+
+// decodeStream applies the stream's filters. Only the filters used for text content are supported.
+func decodeStream(s *pdfStream, resolve func(any) any) ([]byte, error) {
+	var filters []any
+	switch f := resolve(s.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{f}
+	case pdfArray:
+		filters = f
+	}
+
+	data := s.raw
+	for _, f := range filters {
+		name, _ := resolve(f).(pdfName)
+		var err error
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+		case "ASCIIHexDecode", "AHx":
+			data = (&pdfLexer{data: data}).readHexString()
+		case "ASCII85Decode", "A85":
+			data, err = decodeASCII85(data)
+		default:
+			return nil, fmt.Errorf("unsupported stream filter: %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflate decompresses zlib data. Truncated or slightly corrupt streams are common in the wild,
+// so whatever was decompressed before an error is kept.
+func inflate(data []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inflate stream: %w", err)
+	}
+	defer zr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(zr, maxStreamBytes+1))
+	if len(out) > maxStreamBytes {
+		return nil, fmt.Errorf("stream exceeds %d bytes when decompressed", maxStreamBytes)
+	}
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("failed to inflate stream: %w", err)
+	}
+	return out, nil
+}
+
+func decodeASCII85(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("<~"))
+	if i := bytes.Index(data, []byte("~>")); i >= 0 {
+		data = data[:i]
+	}
+	// "z" expands a single character into four zero bytes
+	out := make([]byte, len(data)*4)
+	n, _, err := ascii85.Decode(out, data, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ASCII85 stream: %w", err)
+	}
+	return out[:n], nil
+}
@@ -0,0 +1,255 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildPDF assembles a PDF from object bodies numbered from 1. The cross-reference table is omitted
+// since the parser finds objects by scanning.
+func buildPDF(trailer string, objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	fmt.Fprintf(&b, "trailer\n%s\n%%%%EOF\n", trailer)
+	return b.Bytes()
+}
+
+func stream(dict string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func flateStream(dict string, data []byte) string {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write(data)
+	w.Close()
+	return stream(dict+" /Filter /FlateDecode", b.Bytes())
+}
+
+func TestExtractPDF(t *testing.T) {
+	t.Run("extracts text of each page with inferred word and line breaks", func(t *testing.T) {
+		page1 := []byte(`BT /F1 12 Tf 72 720 Td (Hello) Tj 40 0 Td (World) Tj 0 -14 Td [(Sec) 10 (ond) -300 (line)] TJ ET`)
+		page2 := []byte(`BT /F1 12 Tf 14 TL 72 720 Td (Page \(2\)) Tj T* (next) Tj ET`)
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+			"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+			"<< /Type /Page /Parent 2 0 R /Contents [7 0 R] >>",
+			"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			stream("", page1),
+			flateStream("", page2),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{
+			{Number: 1, Text: "Hello World\nSecond line"},
+			{Number: 2, Text: "Page (2)\nnext"},
+		}, parts)
+	})
+
+	t.Run("extracts only the pages selected by the filter", func(t *testing.T) {
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+			"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+			"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+			"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			stream("", []byte(`BT /F1 12 Tf 72 720 Td (First) Tj ET`)),
+			stream("", []byte(`BT /F1 12 Tf 72 720 Td (Second) Tj ET`)),
+		)
+
+		parts, err := ExtractPDF(data, func(part Part) bool { return part.Number == 2 })
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{
+			{Number: 1},
+			{Number: 2, Text: "Second"},
+		}, parts)
+	})
+
+	t.Run("maps codes through ToUnicode CMaps of composite fonts", func(t *testing.T) {
+		cmap := []byte(`/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0001> <3053> <0002> <3093> endbfchar
+1 beginbfrange <0003> <0005> <0031> endbfrange
+1 beginbfrange <0010> <0011> [<4E16> <754C>] endbfrange
+endcmap`)
+		content := []byte(`BT /F1 10 Tf 1 0 0 1 50 700 Tm <00010002000300040005> Tj 1 0 0 1 50 680 Tm <00100011> Tj ET`)
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 6 0 R >>",
+			"<< /Type /Font /Subtype /Type0 /Encoding /Identity-H /DescendantFonts [<< /DW 1000 >>] /ToUnicode 5 0 R >>",
+			flateStream("", cmap),
+			stream("", content),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "こん123\n世界", parts[0].Text)
+	})
+
+	t.Run("decodes UCS-2 encoded composite fonts without ToUnicode", func(t *testing.T) {
+		content := []byte(`BT /F1 10 Tf 50 700 Td <30423044> Tj ET`)
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+			"<< /Type /Font /Subtype /Type0 /Encoding /UniJIS-UCS2-H >>",
+			stream("", content),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "あい", parts[0].Text)
+	})
+
+	t.Run("applies Differences of simple font encodings", func(t *testing.T) {
+		content := []byte(`BT /F1 10 Tf 50 700 Td <0102> Tj ET`)
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+			"<< /Type /Font /Subtype /TrueType /Encoding << /Differences [1 /bullet /uni3042] >> >>",
+			stream("", content),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "•あ", parts[0].Text)
+	})
+
+	t.Run("reads objects compressed in object streams", func(t *testing.T) {
+		objects := "1 0 2 48 3 93 " +
+			"<< /Type /Catalog /Pages 2 0 R >>             " +
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>     " +
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>"
+		var b bytes.Buffer
+		b.WriteString("%PDF-1.5\n")
+		fmt.Fprintf(&b, "4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n")
+		fmt.Fprintf(&b, "5 0 obj\n%s\nendobj\n", stream("", []byte(`BT /F1 12 Tf 72 720 Td (Compressed) Tj ET`)))
+		fmt.Fprintf(&b, "6 0 obj\n%s\nendobj\n", flateStream("/Type /ObjStm /N 3 /First 12", []byte(objects)))
+		fmt.Fprintf(&b, "7 0 obj\n%s\nendobj\n", stream("/Type /XRef /Root 1 0 R /Size 8", []byte{}))
+		b.WriteString("startxref\n0\n%%EOF\n")
+
+		parts, err := ExtractPDF(b.Bytes(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []Part{{Number: 1, Text: "Compressed"}}, parts)
+	})
+
+	t.Run("extracts text drawn by form XObjects", func(t *testing.T) {
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> /XObject << /X1 6 0 R >> >> /Contents 5 0 R >>",
+			"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			stream("", []byte(`BT /F1 12 Tf 72 720 Td (Body) Tj ET /X1 Do`)),
+			stream("/Type /XObject /Subtype /Form", []byte(`BT /F1 12 Tf 72 40 Td (Footer) Tj ET`)),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Body\nFooter", parts[0].Text)
+	})
+
+	t.Run("skips inline images", func(t *testing.T) {
+		data := buildPDF("<< /Root 1 0 R >>",
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+			"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			stream("", []byte("BI /W 2 /H 1 /BPC 8 /CS /G ID \x28\x29 EI BT /F1 12 Tf 72 720 Td (After image) Tj ET")),
+		)
+
+		parts, err := ExtractPDF(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "After image", parts[0].Text)
+	})
+
+	t.Run("returns ErrEncrypted for encrypted PDFs", func(t *testing.T) {
+		data := buildPDF("<< /Root 1 0 R /Encrypt 2 0 R >>",
+			"<< /Type /Catalog /Pages 3 0 R >>",
+			"<< /Filter /Standard /V 2 >>",
+		)
+
+		_, err := ExtractPDF(data, nil)
+		assert.ErrorIs(t, err, ErrEncrypted)
+	})
+
+	t.Run("returns error for non-PDF data", func(t *testing.T) {
+		_, err := ExtractPDF([]byte(strings.Repeat("x", 2000)), nil)
+		assert.EqualError(t, err, "not a PDF file")
+	})
+
+	t.Run("does not panic on truncated objects and streams", func(t *testing.T) {
+		tests := []struct {
+			name string
+			data string
+		}{
+			{"unterminated hex string", "%PDF-0 0 obj<"},
+			{"unterminated dictionary", "%PDF-1.7\n1 0 obj\n<< /Type /Page"},
+			{"stream keyword at the end", "%PDF-1.7\n1 0 obj\n<< /Length 10 >>\nstream"},
+			{"stream without endstream", "%PDF-1.7\n1 0 obj\n<< /Length 100 >>\nstream\nBT (abc) Tj"},
+			{"Length past the end", "%PDF-1.7\n1 0 obj\n<< /Length 5 >>\nstream\r\n"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.NotPanics(t, func() {
+					_, err := ExtractPDF([]byte(tt.data), nil)
+					assert.Error(t, err)
+				})
+			})
+		}
+	})
+}
+
+func FuzzExtractPDF(f *testing.F) {
+	f.Add([]byte("%PDF-0 0 obj<"))
+	f.Add([]byte("%PDF-1.7\n1 0 obj\n<< /Length 10 >>\nstream"))
+	f.Add(buildPDF("<< /Root 1 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		flateStream("", []byte("BT /F1 12 Tf 72 720 Td (Hello) Tj ET")),
+	))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Only panics fail; malformed input is expected to return an error
+		ExtractPDF(data, nil)
+	})
+}
+
+func TestGlyphNameToRune(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected rune
+		ok       bool
+	}{
+		{"A", 'A', true},
+		{"quoteright", '’', true},
+		{"uni3042", 'あ', true},
+		{"uni00660069", 'f', true},
+		{"u1F600", '😀', true},
+		{"a.sc", 'a', true},
+		{"g123", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := glyphNameToRune(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, r)
+		})
+	}
+}
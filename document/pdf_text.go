@@ -0,0 +1,595 @@
+package document
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxFormDepth bounds form XObjects drawn inside each other
+const maxFormDepth = 8
+
+// Thresholds for inferring word and line breaks from text positioning, relative to the font size
+const (
+	// wordGapRatio is the smallest horizontal gap treated as a space between words
+	wordGapRatio = 0.2
+	// lineGapRatio is the smallest vertical move treated as a new line
+	lineGapRatio = 0.5
+	// tjSpaceThreshold is the TJ adjustment, in thousandths of an em, treated as a space between words
+	tjSpaceThreshold = -200
+	// defaultGlyphWidth is used when a font has no width for a code, in thousandths of an em
+	defaultGlyphWidth = 500
+)
+
+// textExtractor interprets content streams and writes the shown text.
+// Word and line breaks are inferred from text positioning since PDFs rarely contain space characters between positioned runs.
+type textExtractor struct {
+	file  *pdfFile
+	fonts map[pdfRef]*pdfFont
+	out   strings.Builder
+
+	font     *pdfFont
+	fontSize float64
+	// scale is the horizontal scale of the text matrix, converting text space into user space
+	scale float64
+	// lineX and lineY are the origin of the text line matrix, which Td moves relative to, in user space
+	lineX, lineY float64
+	// x and y are where the next glyph is drawn, in user space. They outlive BT/ET blocks to compare positions across them.
+	x, y float64
+	// leading is the line spacing set by TL, used by T*, ' and "
+	leading float64
+}
+
+func newTextExtractor(f *pdfFile) *textExtractor {
+	return &textExtractor{file: f, fonts: map[pdfRef]*pdfFont{}, fontSize: 1, scale: 1}
+}
+
+// run interprets a content stream. Unknown operators are ignored.
+func (e *textExtractor) run(content []byte, resources pdfDict, depth int) {
+	l := &pdfLexer{data: content}
+	var operands []any
+
+	for {
+		token, err := l.next()
+		if err != nil {
+			return
+		}
+		op, ok := token.(pdfKeyword)
+		if !ok || op == tokenArrayStart || op == tokenDictStart {
+			obj, err := l.parseFrom(token, 0)
+			if err != nil {
+				return
+			}
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch op {
+		case "BT":
+			e.scale = 1
+			e.lineX, e.lineY = 0, 0
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(pdfName); ok {
+					e.font = e.loadFont(resources, name)
+				}
+				if size, ok := operands[len(operands)-1].(float64); ok && size != 0 {
+					e.fontSize = math.Abs(size)
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				tx, _ := operands[len(operands)-2].(float64)
+				ty, _ := operands[len(operands)-1].(float64)
+				if op == "TD" {
+					e.leading = -ty
+				}
+				e.moveTo(e.lineX+tx*e.scale, e.lineY+ty*e.scale)
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				a, _ := operands[len(operands)-6].(float64)
+				b, _ := operands[len(operands)-5].(float64)
+				tx, _ := operands[len(operands)-2].(float64)
+				ty, _ := operands[len(operands)-1].(float64)
+				if s := math.Hypot(a, b); s != 0 {
+					e.scale = s
+				}
+				e.moveTo(tx, ty)
+			}
+		case "TL":
+			if len(operands) >= 1 {
+				e.leading, _ = operands[len(operands)-1].(float64)
+			}
+		case "T*":
+			e.nextLine()
+		case "Tj":
+			if len(operands) >= 1 {
+				e.show(operands[len(operands)-1])
+			}
+		case "'", "\"":
+			e.nextLine()
+			if len(operands) >= 1 {
+				e.show(operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				items, _ := operands[len(operands)-1].(pdfArray)
+				for _, item := range items {
+					if adjustment, ok := item.(float64); ok {
+						e.x -= adjustment / 1000 * e.fontSize * e.scale
+						if adjustment <= tjSpaceThreshold {
+							e.space()
+						}
+						continue
+					}
+					e.show(item)
+				}
+			}
+		case "Do":
+			if len(operands) >= 1 && depth < maxFormDepth {
+				if name, ok := operands[len(operands)-1].(pdfName); ok {
+					e.drawForm(resources, name, depth)
+				}
+			}
+		case "BI":
+			skipInlineImage(l)
+		}
+		operands = operands[:0]
+	}
+}
+
+// moveTo starts a new line at the given position, or continues the current line when the vertical move is small
+func (e *textExtractor) moveTo(x, y float64) {
+	size := e.fontSize * e.scale
+	if math.Abs(y-e.y) > size*lineGapRatio {
+		e.newline()
+	} else if math.Abs(x-e.x) > size*wordGapRatio {
+		e.space()
+	}
+	e.lineX, e.lineY = x, y
+	e.x, e.y = x, y
+}
+
+// nextLine moves to the start of the next line, which is always a new line even when the leading is unknown
+func (e *textExtractor) nextLine() {
+	e.newline()
+	e.lineY -= e.leading * e.scale
+	e.x, e.y = e.lineX, e.lineY
+}
+
+func (e *textExtractor) show(operand any) {
+	s, ok := operand.(pdfString)
+	if !ok || e.font == nil {
+		return
+	}
+	text, width := e.font.decode(s)
+	e.out.WriteString(text)
+	e.x += width / 1000 * e.fontSize * e.scale
+}
+
+func (e *textExtractor) space() {
+	if e.out.Len() == 0 {
+		return
+	}
+	if s := e.out.String(); !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+		e.out.WriteByte(' ')
+	}
+}
+
+func (e *textExtractor) newline() {
+	if e.out.Len() > 0 && !strings.HasSuffix(e.out.String(), "\n") {
+		e.out.WriteByte('\n')
+	}
+}
+
+// drawForm interprets a form XObject, which reusable content such as headers and footers is often placed in
+func (e *textExtractor) drawForm(resources pdfDict, name pdfName, depth int) {
+	xobjects := e.file.dict(resources["XObject"])
+	stream, ok := e.file.resolve(xobjects[name]).(*pdfStream)
+	if !ok || stream.dict["Subtype"] != pdfName("Form") {
+		return
+	}
+	content, err := decodeStream(stream, e.file.resolve)
+	if err != nil {
+		return
+	}
+	formResources := e.file.dict(stream.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	e.newline()
+	e.run(content, formResources, depth+1)
+	e.newline()
+}
+
+// skipInlineImage moves past the binary data of an inline image (BI ... ID data EI)
+func skipInlineImage(l *pdfLexer) {
+	for {
+		token, err := l.next()
+		if err != nil {
+			return
+		}
+		if token == pdfKeyword("ID") {
+			break
+		}
+	}
+	for i := l.pos + 1; i+2 <= len(l.data); i++ {
+		if l.data[i] == 'E' && l.data[i+1] == 'I' && isPDFWhitespace(l.data[i-1]) &&
+			(i+2 == len(l.data) || isPDFWhitespace(l.data[i+2])) {
+			l.pos = i + 2
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+func (e *textExtractor) loadFont(resources pdfDict, name pdfName) *pdfFont {
+	fonts := e.file.dict(resources["Font"])
+	value := fonts[name]
+	ref, isRef := value.(pdfRef)
+	if isRef {
+		if font, ok := e.fonts[ref]; ok {
+			return font
+		}
+	}
+	font := newPDFFont(e.file, e.file.dict(value))
+	if isRef {
+		e.fonts[ref] = font
+	}
+	return font
+}
+
+// pdfFont decodes the bytes of shown strings into text and measures their advance
+type pdfFont struct {
+	toUnicode *toUnicodeCMap
+	// composite fonts (Type0) use multi-byte codes, and simple fonts use one byte per code
+	composite bool
+	// utf16 is set for composite fonts whose predefined CMap encodes UCS-2 or UTF-16 (e.g., UniJIS-UCS2-H)
+	utf16 bool
+	// encoding maps single-byte codes of simple fonts to runes
+	encoding [256]rune
+	widths   map[int]float64
+	// defaultWidth is the width of codes missing from widths
+	defaultWidth float64
+}
+
+func newPDFFont(f *pdfFile, dict pdfDict) *pdfFont {
+	font := &pdfFont{encoding: winAnsiEncoding(), widths: map[int]float64{}, defaultWidth: defaultGlyphWidth}
+	if dict == nil {
+		return font
+	}
+
+	if stream, ok := f.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := decodeStream(stream, f.resolve); err == nil {
+			font.toUnicode = parseToUnicodeCMap(data)
+		}
+	}
+
+	if dict["Subtype"] == pdfName("Type0") {
+		font.composite = true
+		if encoding, ok := f.resolve(dict["Encoding"]).(pdfName); ok {
+			font.utf16 = strings.Contains(string(encoding), "UCS2") || strings.Contains(string(encoding), "UTF16")
+		}
+		if descendants, ok := f.resolve(dict["DescendantFonts"]).(pdfArray); ok && len(descendants) > 0 {
+			font.loadCIDWidths(f, f.dict(descendants[0]))
+		}
+		return font
+	}
+
+	switch encoding := f.resolve(dict["Encoding"]).(type) {
+	case pdfDict:
+		if differences, ok := f.resolve(encoding["Differences"]).(pdfArray); ok {
+			font.applyDifferences(f, differences)
+		}
+	}
+
+	firstChar, _ := f.resolve(dict["FirstChar"]).(float64)
+	if widths, ok := f.resolve(dict["Widths"]).(pdfArray); ok {
+		for i, w := range widths {
+			if width, ok := f.resolve(w).(float64); ok {
+				font.widths[int(firstChar)+i] = width
+			}
+		}
+	}
+	return font
+}
+
+// loadCIDWidths reads the W array of a CID font, which holds "c [w1 w2 ...]" and "cfirst clast w" entries
+func (font *pdfFont) loadCIDWidths(f *pdfFile, descendant pdfDict) {
+	if descendant == nil {
+		return
+	}
+	font.defaultWidth = 1000
+	if dw, ok := f.resolve(descendant["DW"]).(float64); ok {
+		font.defaultWidth = dw
+	}
+	w, _ := f.resolve(descendant["W"]).(pdfArray)
+	for i := 0; i+1 < len(w); {
+		first, ok := f.resolve(w[i]).(float64)
+		if !ok {
+			return
+		}
+		if widths, ok := f.resolve(w[i+1]).(pdfArray); ok {
+			for j, width := range widths {
+				if v, ok := f.resolve(width).(float64); ok {
+					font.widths[int(first)+j] = v
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last, _ := f.resolve(w[i+1]).(float64)
+		width, _ := f.resolve(w[i+2]).(float64)
+		for c := int(first); c <= int(last) && c-int(first) < 0x10000; c++ {
+			font.widths[c] = width
+		}
+		i += 3
+	}
+}
+
+func (font *pdfFont) applyDifferences(f *pdfFile, differences pdfArray) {
+	code := 0
+	for _, item := range differences {
+		switch v := f.resolve(item).(type) {
+		case float64:
+			code = int(v)
+		case pdfName:
+			if code >= 0 && code < 256 {
+				if r, ok := glyphNameToRune(string(v)); ok {
+					font.encoding[code] = r
+				}
+			}
+			code++
+		}
+	}
+}
+
+// decode converts a shown string into text and returns its width in thousandths of an em
+func (font *pdfFont) decode(s pdfString) (string, float64) {
+	var b strings.Builder
+	width := 0.0
+
+	if font.toUnicode != nil {
+		for i := 0; i < len(s); {
+			n := font.toUnicode.codeLength(s[i:], font.composite)
+			code := s[i : i+n]
+			text, ok := font.toUnicode.chars[string(code)]
+			if !ok && !font.composite && n == 1 {
+				text = string(font.encoding[code[0]])
+			}
+			b.WriteString(text)
+			width += font.width(bytesToCode(code))
+			i += n
+		}
+		return b.String(), width
+	}
+
+	if font.composite {
+		for i := 0; i+1 < len(s); i += 2 {
+			code := int(s[i])<<8 | int(s[i+1])
+			width += font.width(code)
+		}
+		if font.utf16 {
+			return decodeUTF16BE(s), width
+		}
+		// Without a ToUnicode map the codes are glyph IDs that cannot be mapped to text
+		return "", width
+	}
+
+	for _, c := range s {
+		if r := font.encoding[c]; r != 0 {
+			b.WriteRune(r)
+		}
+		width += font.width(int(c))
+	}
+	return b.String(), width
+}
+
+func (font *pdfFont) width(code int) float64 {
+	if w, ok := font.widths[code]; ok {
+		return w
+	}
+	return font.defaultWidth
+}
+
+func bytesToCode(b []byte) int {
+	code := 0
+	for _, c := range b {
+		code = code<<8 | int(c)
+	}
+	return code
+}
+
+func decodeUTF16BE(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[i*2])<<8 | uint16(b[i*2+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// toUnicodeCMap maps character codes to text as described by a font's ToUnicode stream
+type toUnicodeCMap struct {
+	codespaces []codespaceRange
+	// chars maps code bytes to text
+	chars map[string]string
+}
+
+type codespaceRange struct {
+	low, high []byte
+}
+
+// maxCMapRangeSize bounds the codes expanded from a single bfrange entry
+const maxCMapRangeSize = 0x10000
+
+func parseToUnicodeCMap(data []byte) *toUnicodeCMap {
+	cmap := &toUnicodeCMap{chars: map[string]string{}}
+	l := &pdfLexer{data: data}
+
+	readUntil := func(end pdfKeyword) []any {
+		var objects []any
+		for {
+			token, err := l.next()
+			if err != nil || token == end {
+				return objects
+			}
+			obj, err := l.parseFrom(token, 0)
+			if err != nil {
+				return objects
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	for {
+		token, err := l.next()
+		if err != nil {
+			return cmap
+		}
+		switch token {
+		case pdfKeyword("begincodespacerange"):
+			objects := readUntil("endcodespacerange")
+			for i := 0; i+1 < len(objects); i += 2 {
+				low, ok1 := objects[i].(pdfString)
+				high, ok2 := objects[i+1].(pdfString)
+				if ok1 && ok2 && len(low) == len(high) && len(low) > 0 {
+					cmap.codespaces = append(cmap.codespaces, codespaceRange{low: low, high: high})
+				}
+			}
+		case pdfKeyword("beginbfchar"):
+			objects := readUntil("endbfchar")
+			for i := 0; i+1 < len(objects); i += 2 {
+				src, ok1 := objects[i].(pdfString)
+				dst, ok2 := objects[i+1].(pdfString)
+				if ok1 && ok2 {
+					cmap.chars[string(src)] = decodeUTF16BE(dst)
+				}
+			}
+		case pdfKeyword("beginbfrange"):
+			objects := readUntil("endbfrange")
+			for i := 0; i+2 < len(objects); i += 3 {
+				low, ok1 := objects[i].(pdfString)
+				high, ok2 := objects[i+1].(pdfString)
+				if !ok1 || !ok2 || len(low) != len(high) || len(low) == 0 {
+					continue
+				}
+				cmap.addRange(low, high, objects[i+2])
+			}
+		}
+	}
+}
+
+// addRange expands a bfrange entry. dst is either the text of the first code, incremented for each following code,
+// or an array with the text of every code.
+func (c *toUnicodeCMap) addRange(low, high []byte, dst any) {
+	first, last := bytesToCode(low), bytesToCode(high)
+	if last < first || last-first >= maxCMapRangeSize {
+		return
+	}
+	for code := first; code <= last; code++ {
+		key := make([]byte, len(low))
+		for i, v := len(key)-1, code; i >= 0; i, v = i-1, v>>8 {
+			key[i] = byte(v)
+		}
+
+		switch d := dst.(type) {
+		case pdfString:
+			if len(d) < 2 {
+				continue
+			}
+			units := make([]uint16, len(d)/2)
+			for i := range units {
+				units[i] = uint16(d[i*2])<<8 | uint16(d[i*2+1])
+			}
+			units[len(units)-1] += uint16(code - first)
+			c.chars[string(key)] = string(utf16.Decode(units))
+		case pdfArray:
+			if code-first >= len(d) {
+				return
+			}
+			if s, ok := d[code-first].(pdfString); ok {
+				c.chars[string(key)] = decodeUTF16BE(s)
+			}
+		}
+	}
+}
+
+// codeLength returns the byte length of the code at the start of s, as defined by the codespace ranges.
+// Without ranges, composite fonts use 2-byte codes and simple fonts use 1-byte codes.
+func (c *toUnicodeCMap) codeLength(s []byte, composite bool) int {
+	for n := 1; n <= 4 && n <= len(s); n++ {
+		for _, r := range c.codespaces {
+			if len(r.low) == n && bytes.Compare(s[:n], r.low) >= 0 && bytes.Compare(s[:n], r.high) <= 0 {
+				return n
+			}
+		}
+	}
+	if composite && len(s) >= 2 {
+		return 2
+	}
+	return 1
+}
+
+// winAnsiEncoding returns WinAnsiEncoding, which is also used as the default for simple fonts without an encoding
+func winAnsiEncoding() [256]rune {
+	var encoding [256]rune
+	for i := 32; i < 256; i++ {
+		encoding[i] = rune(i)
+	}
+	encoding['\t'], encoding['\n'], encoding['\r'] = ' ', ' ', ' '
+	copy(encoding[0x80:0xa0], []rune{
+		'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+		0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+	})
+	encoding[0x7f] = 0
+	return encoding
+}
+
+// glyphNames maps the Adobe glyph names commonly found in /Differences arrays that are not single letters
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$', "percent": '%',
+	"ampersand": '&', "quotesingle": '\'', "parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+',
+	"comma": ',', "hyphen": '-', "period": '.', "slash": '/', "zero": '0', "one": '1', "two": '2',
+	"three": '3', "four": '4', "five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"colon": ':', "semicolon": ';', "less": '<', "equal": '=', "greater": '>', "question": '?', "at": '@',
+	"bracketleft": '[', "backslash": '\\', "bracketright": ']', "asciicircum": '^', "underscore": '_',
+	"grave": '`', "braceleft": '{', "bar": '|', "braceright": '}', "asciitilde": '~',
+	"bullet": '•', "endash": '–', "emdash": '—', "quoteleft": '‘', "quoteright": '’',
+	"quotedblleft": '“', "quotedblright": '”', "quotesinglbase": '‚', "quotedblbase": '„',
+	"ellipsis": '…', "dagger": '†', "daggerdbl": '‡', "trademark": '™', "copyright": '©',
+	"registered": '®', "degree": '°', "Euro": '€', "section": '§', "paragraph": '¶',
+	"periodcentered": '·', "multiply": '×', "divide": '÷', "minus": '−', "nbspace": ' ',
+	"fi": 'ﬁ', "fl": 'ﬂ', "ff": 'ﬀ', "ffi": 'ﬃ', "ffl": 'ﬄ',
+}
+
+// glyphNameToRune maps a glyph name such as "A", "uni3042", "u1F600" or "bullet" to a rune.
+// Suffixes such as ".sc" or ".alt" are ignored.
+func glyphNameToRune(name string) (rune, bool) {
+	if base, _, found := strings.Cut(name, "."); found && base != "" {
+		name = base
+	}
+	if len(name) == 1 {
+		return rune(name[0]), true
+	}
+	if r, ok := glyphNames[name]; ok {
+		return r, true
+	}
+	if hexDigits, ok := strings.CutPrefix(name, "uni"); ok && len(hexDigits) >= 4 {
+		// Names like "uni00660069" spell several characters; only the first is kept
+		if v, err := strconv.ParseUint(hexDigits[:4], 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	if hexDigits, ok := strings.CutPrefix(name, "u"); ok && len(hexDigits) >= 4 && len(hexDigits) <= 6 {
+		if v, err := strconv.ParseUint(hexDigits, 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	return 0, false
+}
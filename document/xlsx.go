@@ -0,0 +1,363 @@
+package document
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// builtinDateFormatIDs are the built-in number formats that display dates or times.
+// 27-36 and 50-58 are the locale-specific date formats used by Japanese, Chinese and Korean Excel.
+var builtinDateFormatIDs = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 28: true, 29: true, 30: true, 31: true, 32: true, 33: true, 34: true, 35: true, 36: true,
+	45: true, 46: true, 47: true,
+	50: true, 51: true, 52: true, 53: true, 54: true, 55: true, 56: true, 57: true, 58: true,
+}
+
+// Serial date epochs. The 1900 system starts on 1899-12-30 to absorb Excel's fictional 1900-02-29.
+var (
+	excelEpoch1900 = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	excelEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// ExtractXLSX extracts each worksheet selected by include as tab-separated rows in workbook order. Part.Name holds the sheet name.
+// Cells formatted as dates are rendered as "2006-01-02", "2006-01-02 15:04:05" or "15:04:05".
+func ExtractXLSX(data []byte, include PartFilter) ([]Part, error) {
+	zr, err := openZip(data)
+	if err != nil {
+		return nil, err
+	}
+
+	workbook, err := readZipFile(zr, "xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	rels, err := readRelationships(zr, "xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings []string
+	if findZipFile(zr, "xl/sharedStrings.xml") != nil {
+		data, err := readZipFile(zr, "xl/sharedStrings.xml")
+		if err != nil {
+			return nil, err
+		}
+		if sharedStrings, err = parseSharedStrings(data); err != nil {
+			return nil, fmt.Errorf("failed to parse xl/sharedStrings.xml: %w", err)
+		}
+	}
+
+	var dateStyles []bool
+	if findZipFile(zr, "xl/styles.xml") != nil {
+		data, err := readZipFile(zr, "xl/styles.xml")
+		if err != nil {
+			return nil, err
+		}
+		if dateStyles, err = parseDateStyles(data); err != nil {
+			return nil, fmt.Errorf("failed to parse xl/styles.xml: %w", err)
+		}
+	}
+
+	type sheetRef struct {
+		name string
+		id   string
+	}
+	var sheets []sheetRef
+	epoch := excelEpoch1900
+	decoder := xml.NewDecoder(bytes.NewReader(workbook))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse xl/workbook.xml: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "workbookPr":
+			if v := attrValue(start, "date1904"); v == "1" || v == "true" {
+				epoch = excelEpoch1904
+			}
+		case "sheet":
+			sheets = append(sheets, sheetRef{name: attrValue(start, "name"), id: relationshipID(start)})
+		}
+	}
+
+	parts := make([]Part, 0, len(sheets))
+	for i, sheet := range sheets {
+		part := Part{Number: i + 1, Name: sheet.name}
+		if !include.includes(part) {
+			parts = append(parts, part)
+			continue
+		}
+		target, ok := rels[sheet.id]
+		if !ok {
+			return nil, fmt.Errorf("sheet %q has no relationship %q", sheet.name, sheet.id)
+		}
+		data, err := readZipFile(zr, target)
+		if err != nil {
+			return nil, err
+		}
+		text, err := extractSheetText(data, sharedStrings, dateStyles, epoch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", target, err)
+		}
+		part.Text = text
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// parseSharedStrings returns the shared string table. Phonetic guides (rPh), such as furigana, are skipped.
+func parseSharedStrings(data []byte) ([]string, error) {
+	var result []string
+	var b strings.Builder
+	inText := false
+	phoneticDepth := 0
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				b.Reset()
+			case "t":
+				inText = true
+			case "rPh":
+				phoneticDepth++
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				result = append(result, b.String())
+			case "t":
+				inText = false
+			case "rPh":
+				phoneticDepth--
+			}
+		case xml.CharData:
+			if inText && phoneticDepth == 0 {
+				b.Write(t)
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseDateStyles reports, for each cell style index, whether the style displays a date or time
+func parseDateStyles(data []byte) ([]bool, error) {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := xml.Unmarshal(data, &styles); err != nil {
+		return nil, err
+	}
+
+	customDateFormats := make(map[int]bool, len(styles.NumFmts))
+	for _, f := range styles.NumFmts {
+		customDateFormats[f.ID] = isDateFormatCode(f.Code)
+	}
+
+	dateStyles := make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		if isDate, ok := customDateFormats[xf.NumFmtID]; ok {
+			dateStyles[i] = isDate
+		} else {
+			dateStyles[i] = builtinDateFormatIDs[xf.NumFmtID]
+		}
+	}
+	return dateStyles, nil
+}
+
+// isDateFormatCode reports whether a custom number format displays a date or time,
+// ignoring quoted literals, escaped characters and bracketed sections such as colors and locales
+func isDateFormatCode(code string) bool {
+	inQuote, inBracket, escaped := false, false, false
+	for _, r := range strings.ToLower(code) {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote:
+			inQuote = r != '"'
+		case inBracket:
+			inBracket = r != ']'
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuote = true
+		case r == '[':
+			inBracket = true
+		case strings.ContainsRune("ymdhs", r):
+			return true
+		}
+	}
+	return false
+}
+
+// extractSheetText renders the non-empty rows of a worksheet with tab-separated cells placed by their column
+func extractSheetText(data []byte, sharedStrings []string, dateStyles []bool, epoch time.Time) (string, error) {
+	var lines []string
+	var row []string
+	var value strings.Builder
+	var cellRef, cellType string
+	cellStyle := 0
+	inValue, inInlineText := false, false
+	phoneticDepth := 0
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row = row[:0]
+			case "c":
+				cellRef = attrValue(t, "r")
+				cellType = attrValue(t, "t")
+				cellStyle, _ = strconv.Atoi(attrValue(t, "s"))
+				value.Reset()
+			case "v":
+				inValue = true
+			case "t":
+				inInlineText = true
+			case "rPh":
+				phoneticDepth++
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v":
+				inValue = false
+			case "t":
+				inInlineText = false
+			case "rPh":
+				phoneticDepth--
+			case "c":
+				text := cellText(value.String(), cellType, cellStyle, sharedStrings, dateStyles, epoch)
+				column := len(row)
+				if index, ok := columnIndex(cellRef); ok {
+					column = index
+				}
+				for len(row) <= column {
+					row = append(row, "")
+				}
+				row[column] = text
+			case "row":
+				for len(row) > 0 && row[len(row)-1] == "" {
+					row = row[:len(row)-1]
+				}
+				if len(row) > 0 {
+					lines = append(lines, strings.Join(row, "\t"))
+				}
+			}
+		case xml.CharData:
+			if inValue || (inInlineText && phoneticDepth == 0) {
+				value.Write(t)
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// cellText converts a raw cell value into display text. Tabs and line breaks become spaces to keep one row per line.
+func cellText(raw, cellType string, style int, sharedStrings []string, dateStyles []bool, epoch time.Time) string {
+	var text string
+	switch cellType {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err == nil && index >= 0 && index < len(sharedStrings) {
+			text = sharedStrings[index]
+		}
+	case "b":
+		if strings.TrimSpace(raw) == "1" {
+			text = "TRUE"
+		} else {
+			text = "FALSE"
+		}
+	case "", "n":
+		text = raw
+		if style >= 0 && style < len(dateStyles) && dateStyles[style] {
+			if serial, err := strconv.ParseFloat(raw, 64); err == nil {
+				text = formatSerialDate(serial, epoch)
+			}
+		}
+	default:
+		// inlineStr, str (formula result), e (error) and d (ISO 8601 date) are already display text
+		text = raw
+	}
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(text)
+}
+
+// formatSerialDate formats an Excel serial date, where the integer part counts days and the fraction is the time of day
+func formatSerialDate(serial float64, epoch time.Time) string {
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 24 * 60 * 60)
+	t := epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+	switch {
+	case days == 0 && seconds > 0:
+		return t.Format("15:04:05")
+	case seconds == 0:
+		return t.Format("2006-01-02")
+	default:
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
+// columnIndex converts the column letters of a cell reference such as "AB12" into a 0-based index
+func columnIndex(ref string) (int, bool) {
+	index := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A') + 1
+		letters++
+	}
+	if letters == 0 {
+		return 0, false
+	}
+	return index - 1, true
+}
+
+func attrValue(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name && attr.Name.Space == "" {
+			return attr.Value
+		}
+	}
+	return ""
+}
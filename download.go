@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// maxCanvasDownloadBytes caps the size of a single canvas download
+const maxCanvasDownloadBytes = 10 << 20
+
+// downloadTooLargeError is returned by limitedWriter once the limit is exceeded
+type downloadTooLargeError struct {
+	limit int64
+}
+
+func (e *downloadTooLargeError) Error() string {
+	return fmt.Sprintf("file exceeds the download limit of %d bytes", e.limit)
+}
+
+// limitedWriter buffers up to limit bytes and fails as soon as more are written
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int64
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	remaining := w.limit - int64(w.buf.Len())
+	if int64(len(p)) > remaining {
+		w.buf.Write(p[:max(remaining, 0)])
+		return int(max(remaining, 0)), &downloadTooLargeError{limit: w.limit}
+	}
+	return w.buf.Write(p)
+}
+
+// downloadFile downloads a private Slack file into memory, failing once it grows beyond limit bytes
+func downloadFile(ctx context.Context, client SlackClient, downloadURL string, limit int64) ([]byte, error) {
+	w := &limitedWriter{limit: limit}
+	if err := client.GetFile(ctx, downloadURL, w); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
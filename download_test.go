@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDownloadFile(t *testing.T) {
+	t.Run("returns content within the limit", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/file", mock.Anything).Run(func(args mock.Arguments) {
+			w := args.Get(2).(io.Writer)
+			_, _ = w.Write([]byte("hello "))
+			_, _ = w.Write([]byte("world"))
+		}).Return(nil)
+
+		data, err := downloadFile(t.Context(), mockClient, "https://files.slack.com/file", 11)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(data))
+	})
+
+	t.Run("returns the download error", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/file", mock.Anything).Return(errors.New("network error"))
+
+		data, err := downloadFile(t.Context(), mockClient, "https://files.slack.com/file", 10)
+		assert.Nil(t, data)
+		assert.EqualError(t, err, "network error")
+	})
+}
+
+func TestLimitedWriter(t *testing.T) {
+	w := &limitedWriter{limit: 10}
+
+	n, err := w.Write([]byte("hello "))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	n, err = w.Write([]byte("world"))
+	assert.Equal(t, 4, n)
+	var tooLarge *downloadTooLargeError
+	assert.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, "file exceeds the download limit of 10 bytes", err.Error())
+	assert.Equal(t, "hello worl", w.buf.String())
+
+	n, err = w.Write([]byte("!"))
+	assert.Equal(t, 0, n)
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"mime"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// textFiletypes are Slack filetypes whose content is text regardless of the reported mimetype
+var textFiletypes = map[string]bool{
+	"text":     true,
+	"csv":      true,
+	"tsv":      true,
+	"markdown": true,
+	"json":     true,
+	"xml":      true,
+	"yaml":     true,
+	"html":     true,
+	"email":    true,
+	"post":     true,
+	"space":    true,
+	"diff":     true,
+	"sql":      true,
+	"shell":    true,
+}
+
+// textMimetypes are non-"text/" mimetypes whose content is text
+var textMimetypes = map[string]bool{
+	"application/json":       true,
+	"application/x-ndjson":   true,
+	"application/xml":        true,
+	"application/javascript": true,
+	"application/x-yaml":     true,
+	"application/yaml":       true,
+	"application/x-sh":       true,
+	"application/sql":        true,
+	"message/rfc822":         true,
+}
+
+// isCanvasFile reports whether the file is a canvas, which get_canvas_content handles instead
+func isCanvasFile(file *slack.File) bool {
+	return file.Mode == "canvas" || file.Filetype == "quip"
+}
+
+// isTextFile reports whether the file content can be returned as text, judging by its mode, filetype and mimetype.
+// Snippets are always text since their filetype is the snippet language (e.g., "go", "python").
+func isTextFile(file *slack.File) bool {
+	if file.Mode == "snippet" || textFiletypes[file.Filetype] {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(file.Mimetype)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || textMimetypes[mediaType] {
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// Encodings reported by decodeText
+const (
+	encodingUTF8         = "utf-8"
+	encodingUTF8Replaced = "utf-8 (invalid bytes replaced)"
+	encodingUTF16LE      = "utf-16le"
+	encodingUTF16BE      = "utf-16be"
+	encodingISO88591     = "iso-8859-1"
+	encodingWindows1252  = "windows-1252"
+)
+
+const (
+	utf8ByteOrderMark    = "\xef\xbb\xbf"
+	utf16LEByteOrderMark = "\xff\xfe"
+	utf16BEByteOrderMark = "\xfe\xff"
+)
+
+// binarySniffLength is how much of the decoded text looksBinary inspects
+const binarySniffLength = 8000
+
+// decodeText converts file content into UTF-8 and reports the detected encoding.
+// A byte order mark wins over the charset declared in mimetype, which in turn wins over UTF-8 validation.
+// Content that is neither valid UTF-8 nor declared otherwise has its invalid bytes replaced with U+FFFD.
+func decodeText(data []byte, mimetype string) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte(utf8ByteOrderMark)):
+		return strings.ToValidUTF8(string(data[len(utf8ByteOrderMark):]), "\uFFFD"), encodingUTF8
+	case bytes.HasPrefix(data, []byte(utf16LEByteOrderMark)):
+		return decodeUTF16(data[len(utf16LEByteOrderMark):], binary.LittleEndian), encodingUTF16LE
+	case bytes.HasPrefix(data, []byte(utf16BEByteOrderMark)):
+		return decodeUTF16(data[len(utf16BEByteOrderMark):], binary.BigEndian), encodingUTF16BE
+	}
+
+	if _, params, err := mime.ParseMediaType(mimetype); err == nil {
+		switch strings.ToLower(params["charset"]) {
+		case "utf-16le", "utf-16":
+			return decodeUTF16(data, binary.LittleEndian), encodingUTF16LE
+		case "utf-16be":
+			return decodeUTF16(data, binary.BigEndian), encodingUTF16BE
+		case "iso-8859-1", "latin1", "l1":
+			return decodeISO88591(data), encodingISO88591
+		case "windows-1252", "cp1252":
+			return decodeWindows1252(data), encodingWindows1252
+		}
+	}
+
+	if utf8.Valid(data) {
+		return string(data), encodingUTF8
+	}
+	return strings.ToValidUTF8(string(data), "\uFFFD"), encodingUTF8Replaced
+}
+
+// looksBinary reports whether decoded text contains a NUL character near its start, which text files never do
+func looksBinary(text string) bool {
+	return strings.IndexByte(text[:min(len(text), binarySniffLength)], 0) >= 0
+}
+
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func decodeISO88591(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// windows1252Specials maps 0x80-0x9F, where windows-1252 differs from iso-8859-1. Undefined bytes map to U+FFFD.
+var windows1252Specials = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+func decodeWindows1252(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		if b >= 0x80 && b <= 0x9F {
+			runes[i] = windows1252Specials[b-0x80]
+		} else {
+			runes[i] = rune(b)
+		}
+	}
+	return string(runes)
+}
+
+// splitLines splits text into lines, accepting both "\n" and "\r\n". A trailing newline does not start a new line.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	return strings.Split(text, "\n")
+}
+
+// joinLinesWithin joins at most maxLines lines with "\n", cutting the result at maxChars characters.
+// It returns the joined text, the number of lines it contains (the last one possibly partial) and whether anything was left out.
+func joinLinesWithin(lines []string, maxLines, maxChars int) (string, int, bool) {
+	var b strings.Builder
+	remaining := maxChars
+	for i, line := range lines {
+		if i == maxLines {
+			return b.String(), i, true
+		}
+		if i > 0 {
+			// Stop rather than return a separator followed by nothing of the next line
+			if remaining <= 1 {
+				return b.String(), i, true
+			}
+			b.WriteString("\n")
+			remaining--
+		}
+		if runes := []rune(line); len(runes) > remaining {
+			b.WriteString(string(runes[:remaining]))
+			return b.String(), i + 1, true
+		}
+		b.WriteString(line)
+		remaining -= utf8.RuneCountInString(line)
+	}
+	return b.String(), len(lines), false
+}
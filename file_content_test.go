@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTextFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     slack.File
+		expected bool
+	}{
+		{"snippet in any language", slack.File{Mode: "snippet", Filetype: "go", Mimetype: "text/plain"}, true},
+		{"csv filetype", slack.File{Mode: "hosted", Filetype: "csv", Mimetype: "application/vnd.ms-excel"}, true},
+		{"text mimetype", slack.File{Mode: "hosted", Filetype: "log", Mimetype: "text/x-log; charset=utf-8"}, true},
+		{"json mimetype", slack.File{Mode: "hosted", Filetype: "binary", Mimetype: "application/json"}, true},
+		{"structured syntax suffix", slack.File{Mode: "hosted", Filetype: "binary", Mimetype: "application/ld+json"}, true},
+		{"image", slack.File{Mode: "hosted", Filetype: "png", Mimetype: "image/png"}, false},
+		{"pdf", slack.File{Mode: "hosted", Filetype: "pdf", Mimetype: "application/pdf"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTextFile(&tt.file))
+		})
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             []byte
+		mimetype         string
+		expectedText     string
+		expectedEncoding string
+	}{
+		{"utf-8", []byte("こんにちは"), "text/plain", "こんにちは", "utf-8"},
+		{"utf-8 with byte order mark", []byte("\xef\xbb\xbfhello"), "text/plain", "hello", "utf-8"},
+		{"utf-16le with byte order mark", []byte("\xff\xfeh\x00i\x00"), "text/plain", "hi", "utf-16le"},
+		{"utf-16be with byte order mark", []byte("\xfe\xff\x00h\x00i"), "text/plain", "hi", "utf-16be"},
+		{"utf-16le declared by charset", []byte("h\x00i\x00"), "text/plain; charset=UTF-16LE", "hi", "utf-16le"},
+		{"iso-8859-1 declared by charset", []byte("caf\xe9"), "text/plain; charset=ISO-8859-1", "café", "iso-8859-1"},
+		{"windows-1252 declared by charset", []byte("\x93quoted\x94 \x80"), "text/plain; charset=windows-1252", "“quoted” €", "windows-1252"},
+		{"invalid utf-8 without charset", []byte("ok\xff"), "text/plain", "ok�", "utf-8 (invalid bytes replaced)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, encoding := decodeText(tt.data, tt.mimetype)
+			assert.Equal(t, tt.expectedText, text)
+			assert.Equal(t, tt.expectedEncoding, encoding)
+		})
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Nil(t, splitLines(""))
+	assert.Equal(t, []string{"a", "b"}, splitLines("a\nb\n"))
+	assert.Equal(t, []string{"a", "", "b"}, splitLines("a\r\n\r\nb"))
+}
+
+func TestJoinLinesWithin(t *testing.T) {
+	tests := []struct {
+		name              string
+		lines             []string
+		maxLines          int
+		maxChars          int
+		expectedText      string
+		expectedLines     int
+		expectedTruncated bool
+	}{
+		{"fits", []string{"ab", "cd"}, 10, 100, "ab\ncd", 2, false},
+		{"exactly fits", []string{"ab", "cd"}, 2, 5, "ab\ncd", 2, false},
+		{"stops at maxLines", []string{"ab", "cd", "ef"}, 2, 100, "ab\ncd", 2, true},
+		{"cuts the last line at maxChars", []string{"ab", "cdef"}, 10, 5, "ab\ncd", 2, true},
+		{"cuts a single long line", []string{"abcdef"}, 10, 3, "abc", 1, true},
+		{"does not end with a bare separator", []string{"ab", "cd"}, 10, 3, "ab", 1, true},
+		{"counts characters, not bytes", []string{"あいう", "えお"}, 10, 5, "あいう\nえ", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, lines, truncated := joinLinesWithin(tt.lines, tt.maxLines, tt.maxChars)
+			assert.Equal(t, tt.expectedText, text)
+			assert.Equal(t, tt.expectedLines, lines)
+			assert.Equal(t, tt.expectedTruncated, truncated)
+		})
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/slack-go/slack v0.17.3
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/net v0.49.0
+	golang.org/x/text v0.33.0
 )
 
 require (
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/slack-go/slack"
 )
@@ -65,30 +66,76 @@ type UserProfile struct {
 	DisplayName string `json:"display_name,omitempty"`
 	RealName    string `json:"real_name,omitempty"`
 	Email       string `json:"email,omitempty"`
-	Error       string `json:"error,omitempty"`
+	Title       string `json:"title,omitempty"`
+	// The fields below are only set when selected with the fields parameter of get_user_profiles.
+	// Pointers keep false and a zero offset in the output once selected.
+	TZ               string               `json:"tz,omitempty"`
+	TZOffset         *int                 `json:"tz_offset,omitempty"`
+	StatusText       string               `json:"status_text,omitempty"`
+	StatusEmoji      string               `json:"status_emoji,omitempty"`
+	StatusExpiration int                  `json:"status_expiration,omitempty"`
+	IsBot            *bool                `json:"is_bot,omitempty"`
+	IsAdmin          *bool                `json:"is_admin,omitempty"`
+	Deleted          *bool                `json:"deleted,omitempty"`
+	IsRestricted     *bool                `json:"is_restricted,omitempty"`
+	CustomFields     []CustomProfileField `json:"custom_fields,omitempty"`
+	// MatchedField is the field that matched the query in search_users_by_name
+	MatchedField string `json:"matched_field,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// CustomProfileField is a workspace-defined profile field with its label resolved from team.profile.get
+type CustomProfileField struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+	Alt   string `json:"alt,omitempty"`
 }
 
 // Handler struct implements the MCP handler
 type Handler struct {
-	getClient      func(ctx context.Context) (SlackClient, error)
-	userRepository *UserRepository
+	getClient           func(ctx context.Context) (SlackClient, error)
+	userRepository      *UserRepository
+	channelRepository   *ChannelRepository
+	workspaceRepository *WorkspaceRepository
+	userGroupRepository *UserGroupRepository
+	// maxConcurrency limits parallel Slack API calls within a single tool call
+	maxConcurrency int
 }
 
 // NewHandler creates a new handler with Slack client
 func NewHandler() *Handler {
+	retryConfig := LoadRetryConfigFromEnv()
 	return &Handler{
 		getClient: func(ctx context.Context) (SlackClient, error) {
 			token, err := SlackUserTokenFromContext(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get Slack token from context: %w", err)
 			}
-			return NewSlackClient(token), nil
+			return NewRetryingSlackClient(NewSlackClient(token), retryConfig), nil
 		},
-		userRepository: NewUserRepository(),
+		userRepository:      NewUserRepository(),
+		channelRepository:   NewChannelRepository(),
+		workspaceRepository: NewWorkspaceRepository(),
+		userGroupRepository: NewUserGroupRepository(),
+		maxConcurrency:      LoadMaxConcurrencyFromEnv(),
 	}
 }
 
 // Close releases resources owned by Handler.
 func (h *Handler) Close() {
 	h.userRepository.Close()
+	h.channelRepository.Close()
+	h.workspaceRepository.Close()
+	h.userGroupRepository.Close()
+}
+
+// lookupWorkspaceURL returns the workspace URL used to build permalinks, or "" when it cannot be discovered
+func (h *Handler) lookupWorkspaceURL(ctx context.Context, client SlackClient) string {
+	workspaceURL, err := h.workspaceRepository.GetWorkspaceURL(ctx, client)
+	if err != nil {
+		slog.Debug("failed to look up workspace URL", "error", err)
+		return ""
+	}
+	return workspaceURL
 }
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -21,7 +20,16 @@ type CanvasContent struct {
 	Title     string `json:"title,omitempty"`
 	Content   string `json:"content,omitempty"`
 	Permalink string `json:"permalink,omitempty"`
-	Error     string `json:"error,omitempty"`
+	// Outline is the heading tree, set instead of Content when outline is requested
+	Outline []CanvasSection `json:"outline,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// canvasContentOptions controls what getCanvasContent returns for each canvas
+type canvasContentOptions struct {
+	Format   string
+	Outline  bool
+	Sections []string
 }
 
 // GetCanvasContent retrieves content for multiple canvases
@@ -40,12 +48,24 @@ func (h *Handler) GetCanvasContent(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError("canvas_ids cannot exceed 20 entries"), nil
 	}
 
-	var canvases []CanvasContent
+	format := request.GetString("format", "html")
+	if format != "html" && format != "markdown" && format != "text" {
+		return mcp.NewToolResultError(fmt.Sprintf("format must be 'html', 'markdown' or 'text', got '%s'", format)), nil
+	}
 
-	for _, canvasID := range canvasIDs {
-		canvas := h.getCanvasContent(client, canvasID)
-		canvases = append(canvases, canvas)
+	options := canvasContentOptions{
+		Format:   format,
+		Outline:  request.GetBool("outline", false),
+		Sections: request.GetStringSlice("sections", nil),
 	}
+	if options.Outline && len(options.Sections) > 0 {
+		return mcp.NewToolResultError("outline and sections cannot be used together"), nil
+	}
+
+	canvases := make([]CanvasContent, len(canvasIDs))
+	forEachConcurrently(len(canvasIDs), h.maxConcurrency, func(i int) {
+		canvases[i] = h.getCanvasContent(ctx, client, canvasIDs[i], options)
+	})
 
 	response := GetCanvasContentResponse{
 		Canvases: canvases,
@@ -59,7 +79,7 @@ func (h *Handler) GetCanvasContent(ctx context.Context, request mcp.CallToolRequ
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
-func (h *Handler) getCanvasContent(client SlackClient, canvasID string) CanvasContent {
+func (h *Handler) getCanvasContent(ctx context.Context, client SlackClient, canvasID string, options canvasContentOptions) CanvasContent {
 	if !strings.HasPrefix(canvasID, "F") {
 		return CanvasContent{
 			ID:    canvasID,
@@ -67,7 +87,7 @@ func (h *Handler) getCanvasContent(client SlackClient, canvasID string) CanvasCo
 		}
 	}
 
-	fileInfo, err := client.GetFileInfo(canvasID)
+	fileInfo, err := client.GetFileInfo(ctx, canvasID)
 	if err != nil {
 		return CanvasContent{
 			ID:    canvasID,
@@ -86,8 +106,14 @@ func (h *Handler) getCanvasContent(client SlackClient, canvasID string) CanvasCo
 		}
 	}
 
-	var buf bytes.Buffer
-	err = client.GetFile(downloadURL, &buf)
+	if int64(fileInfo.Size) > maxCanvasDownloadBytes {
+		return CanvasContent{
+			ID:    canvasID,
+			Error: fmt.Sprintf("failed to download file: %v", &downloadTooLargeError{limit: maxCanvasDownloadBytes}),
+		}
+	}
+
+	data, err := downloadFile(ctx, client, downloadURL, maxCanvasDownloadBytes)
 	if err != nil {
 		return CanvasContent{
 			ID:    canvasID,
@@ -95,12 +121,46 @@ func (h *Handler) getCanvasContent(client SlackClient, canvasID string) CanvasCo
 		}
 	}
 
-	stripper := NewCanvasHTMLStripper()
-	content, err := stripper.Strip(buf.String())
+	htmlStr := string(data)
+	if options.Outline || len(options.Sections) > 0 {
+		doc, err := ParseCanvasDocument(htmlStr)
+		if err != nil {
+			return CanvasContent{
+				ID:    canvasID,
+				Error: fmt.Sprintf("failed to parse HTML: %v", err),
+			}
+		}
+
+		if options.Outline {
+			outline, err := doc.Outline(canvasContentSize(options.Format))
+			if err != nil {
+				return CanvasContent{
+					ID:    canvasID,
+					Error: err.Error(),
+				}
+			}
+			return CanvasContent{
+				ID:        canvasID,
+				Title:     fileInfo.Title,
+				Permalink: fileInfo.Permalink,
+				Outline:   outline,
+			}
+		}
+
+		htmlStr, err = doc.SectionsHTML(options.Sections)
+		if err != nil {
+			return CanvasContent{
+				ID:    canvasID,
+				Error: err.Error(),
+			}
+		}
+	}
+
+	content, err := convertCanvasHTML(htmlStr, options.Format)
 	if err != nil {
 		return CanvasContent{
 			ID:    canvasID,
-			Error: fmt.Sprintf("failed to strip HTML: %v", err),
+			Error: err.Error(),
 		}
 	}
 
@@ -111,3 +171,27 @@ func (h *Handler) getCanvasContent(client SlackClient, canvasID string) CanvasCo
 		Permalink: fileInfo.Permalink,
 	}
 }
+
+// convertCanvasHTML converts downloaded canvas HTML into the requested format
+func convertCanvasHTML(htmlStr string, format string) (string, error) {
+	switch format {
+	case "markdown":
+		content, err := NewCanvasMarkdownConverter().Convert(htmlStr)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert HTML to markdown: %w", err)
+		}
+		return content, nil
+	case "text":
+		content, err := NewCanvasTextConverter().Convert(htmlStr)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert HTML to text: %w", err)
+		}
+		return content, nil
+	default:
+		content, err := NewCanvasHTMLStripper().Strip(htmlStr)
+		if err != nil {
+			return "", fmt.Errorf("failed to strip HTML: %w", err)
+		}
+		return content, nil
+	}
+}
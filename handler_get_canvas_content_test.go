@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
@@ -16,25 +17,25 @@ import (
 func TestHandler_GetCanvasContent(t *testing.T) {
 	t.Run("can get multiple canvases content", func(t *testing.T) {
 		mockClient := &SlackClientMock{}
-		mockClient.On("GetFileInfo", "F1234567").Return(&slack.File{
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
 			ID:                 "F1234567",
 			Title:              "Canvas 1",
 			URLPrivateDownload: "https://files.slack.com/F1234567.html",
 			Permalink:          "https://workspace.slack.com/files/U123/F1234567/canvas_1",
 		}, nil)
-		mockClient.On("GetFile", "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
-			w := args.Get(1).(io.Writer)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+			w := args.Get(2).(io.Writer)
 			w.Write([]byte("<html>Content 1</html>"))
 		}).Return(nil)
 
-		mockClient.On("GetFileInfo", "F2345678").Return(&slack.File{
+		mockClient.On("GetFileInfo", mock.Anything, "F2345678").Return(&slack.File{
 			ID:                 "F2345678",
 			Title:              "Canvas 2",
 			URLPrivateDownload: "https://files.slack.com/F2345678.html",
 			Permalink:          "https://workspace.slack.com/files/U123/F2345678/canvas_2",
 		}, nil)
-		mockClient.On("GetFile", "https://files.slack.com/F2345678.html", mock.Anything).Run(func(args mock.Arguments) {
-			w := args.Get(1).(io.Writer)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F2345678.html", mock.Anything).Run(func(args mock.Arguments) {
+			w := args.Get(2).(io.Writer)
 			w.Write([]byte("<html>Content 2</html>"))
 		}).Return(nil)
 
@@ -144,19 +145,19 @@ func TestHandler_GetCanvasContent(t *testing.T) {
 		mockClient := &SlackClientMock{}
 
 		// Success case
-		mockClient.On("GetFileInfo", "F1234567").Return(&slack.File{
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
 			ID:                 "F1234567",
 			Title:              "Success Canvas",
 			URLPrivateDownload: "https://files.slack.com/F1234567.html",
 			Permalink:          "https://workspace.slack.com/files/U123/F1234567/success_canvas",
 		}, nil)
-		mockClient.On("GetFile", "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
-			w := args.Get(1).(io.Writer)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+			w := args.Get(2).(io.Writer)
 			w.Write([]byte("<html>Success</html>"))
 		}).Return(nil)
 
 		// Error: file has no download URL (both URLPrivateDownload and URLPrivate are empty)
-		mockClient.On("GetFileInfo", "F2345678").Return(&slack.File{
+		mockClient.On("GetFileInfo", mock.Anything, "F2345678").Return(&slack.File{
 			ID:                 "F2345678",
 			Title:              "No URL Canvas",
 			URLPrivateDownload: "",
@@ -164,12 +165,12 @@ func TestHandler_GetCanvasContent(t *testing.T) {
 		}, nil)
 
 		// Error: download failed
-		mockClient.On("GetFileInfo", "F3456789").Return(&slack.File{
+		mockClient.On("GetFileInfo", mock.Anything, "F3456789").Return(&slack.File{
 			ID:                 "F3456789",
 			Title:              "Download Fail Canvas",
 			URLPrivateDownload: "https://files.slack.com/F3456789.html",
 		}, nil)
-		mockClient.On("GetFile", "https://files.slack.com/F3456789.html", mock.Anything).Return(errors.New("network error"))
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F3456789.html", mock.Anything).Return(errors.New("network error"))
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -224,4 +225,345 @@ func TestHandler_GetCanvasContent(t *testing.T) {
 
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("fetches canvases in parallel and keeps input order", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		canvasIDs := []string{"F0000001", "F0000002", "F0000003", "F0000004"}
+		for i, id := range canvasIDs {
+			url := "https://files.slack.com/" + id + ".html"
+			mockClient.On("GetFileInfo", mock.Anything, id).Return(&slack.File{
+				ID:                 id,
+				Title:              "Canvas " + id,
+				URLPrivateDownload: url,
+			}, nil)
+			// Earlier canvases finish later so that completion order differs from input order
+			delay := time.Duration(len(canvasIDs)-i) * 5 * time.Millisecond
+			mockClient.On("GetFile", mock.Anything, url, mock.Anything).Run(func(args mock.Arguments) {
+				time.Sleep(delay)
+				args.Get(2).(io.Writer).Write([]byte("<p>" + id + "</p>"))
+			}).Return(nil)
+		}
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			maxConcurrency: 4,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": canvasIDs,
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		canvases := response["canvases"].([]interface{})
+		assert.Equal(t, len(canvasIDs), len(canvases))
+		for i, id := range canvasIDs {
+			canvas := canvases[i].(map[string]interface{})
+			assert.Equal(t, id, canvas["id"])
+			assert.Equal(t, "<p>"+id+"</p>", canvas["content"])
+		}
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects canvases larger than the download limit", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
+			ID:                 "F1234567",
+			Size:               maxCanvasDownloadBytes + 1,
+			URLPrivateDownload: "https://files.slack.com/F1234567.html",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		canvas := response["canvases"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "failed to download file: file exceeds the download limit of 10485760 bytes", canvas["error"])
+
+		mockClient.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("converts canvases to the requested format", func(t *testing.T) {
+		testCases := []struct {
+			format   string
+			expected string
+		}{
+			{"html", "<h1>Title</h1><p><b>Body</b></p>"},
+			{"markdown", "# Title\n\n**Body**"},
+			{"text", "Title\n\nBody"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.format, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
+					ID:                 "F1234567",
+					URLPrivateDownload: "https://files.slack.com/F1234567.html",
+				}, nil)
+				mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(2).(io.Writer).Write([]byte("<h1 id='temp:C:AAA'>Title</h1><p class='line'><b>Body</b></p>"))
+				}).Return(nil)
+
+				handler := &Handler{
+					getClient: func(ctx context.Context) (SlackClient, error) {
+						return mockClient, nil
+					},
+				}
+
+				req := mcp.CallToolRequest{
+					Params: struct {
+						Name      string    `json:"name"`
+						Arguments any       `json:"arguments,omitempty"`
+						Meta      *mcp.Meta `json:"_meta,omitempty"`
+					}{
+						Name: "get_canvas_content",
+						Arguments: map[string]interface{}{
+							"canvas_ids": []string{"F1234567"},
+							"format":     tc.format,
+						},
+					},
+				}
+				res, err := handler.GetCanvasContent(t.Context(), req)
+				assert.NoError(t, err)
+
+				var response map[string]interface{}
+				err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+				assert.NoError(t, err)
+
+				canvas := response["canvases"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, tc.expected, canvas["content"])
+			})
+		}
+	})
+
+	t.Run("returns error for unknown format", func(t *testing.T) {
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return &SlackClientMock{}, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+					"format":     "pdf",
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "format must be 'html', 'markdown' or 'text', got 'pdf'", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns the outline instead of content", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
+			ID:                 "F1234567",
+			Title:              "Canvas 1",
+			URLPrivateDownload: "https://files.slack.com/F1234567.html",
+		}, nil)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(io.Writer).Write([]byte("<h1 id='temp:C:AAA'>Title</h1><h2 id='temp:C:BBB'>Sub</h2><p class='line'>Body</p>"))
+		}).Return(nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+					"format":     "markdown",
+					"outline":    true,
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		canvas := response["canvases"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "Canvas 1", canvas["title"])
+		assert.NotContains(t, canvas, "content")
+
+		outline := canvas["outline"].([]interface{})
+		assert.Equal(t, 1, len(outline))
+		title := outline[0].(map[string]interface{})
+		assert.Equal(t, "temp:C:AAA", title["id"])
+		assert.Equal(t, "Title", title["title"])
+		assert.Equal(t, float64(1), title["level"])
+		// "# Title\n\n## Sub\n\nBody"
+		assert.Equal(t, float64(21), title["size"])
+
+		sub := title["sections"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "temp:C:BBB", sub["id"])
+		assert.Equal(t, "Sub", sub["title"])
+		assert.Equal(t, float64(2), sub["level"])
+		// "## Sub\n\nBody"
+		assert.Equal(t, float64(12), sub["size"])
+	})
+
+	t.Run("returns only the requested sections", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
+			ID:                 "F1234567",
+			Title:              "Canvas 1",
+			URLPrivateDownload: "https://files.slack.com/F1234567.html",
+		}, nil)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(io.Writer).Write([]byte("<h1 id='temp:C:AAA'>First</h1><p class='line'>One</p><h1 id='temp:C:BBB'>Second</h1><p class='line'>Two</p>"))
+		}).Return(nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+					"format":     "markdown",
+					"sections":   []string{"temp:C:BBB"},
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		canvas := response["canvases"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "# Second\n\nTwo", canvas["content"])
+	})
+
+	t.Run("returns per-canvas error for unknown section", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{
+			ID:                 "F1234567",
+			URLPrivateDownload: "https://files.slack.com/F1234567.html",
+		}, nil)
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/F1234567.html", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(io.Writer).Write([]byte("<h1 id='temp:C:AAA'>First</h1>"))
+		}).Return(nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+					"sections":   []string{"temp:C:ZZZ"},
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		canvas := response["canvases"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "section not found: 'temp:C:ZZZ'. Use outline to list section IDs", canvas["error"])
+	})
+
+	t.Run("returns error when outline and sections are both given", func(t *testing.T) {
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return &SlackClientMock{}, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_canvas_content",
+				Arguments: map[string]interface{}{
+					"canvas_ids": []string{"F1234567"},
+					"outline":    true,
+					"sections":   []string{"temp:C:AAA"},
+				},
+			},
+		}
+		res, err := handler.GetCanvasContent(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "outline and sections cannot be used together", res.Content[0].(mcp.TextContent).Text)
+	})
 }
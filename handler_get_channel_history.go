@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// GetChannelHistoryResponse represents the response structure for get_channel_history
+type GetChannelHistoryResponse struct {
+	Channel    *ChannelInfo    `json:"channel,omitempty"`
+	Messages   []ThreadMessage `json:"messages"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// GetChannelHistory handles the get_channel_history tool call
+func (h *Handler) GetChannelHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	channelID := request.GetString("channel_id", "")
+	var channel *ChannelInfo
+	if channelID != "" {
+		channel, err = h.resolveChannelID(ctx, client, channelID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		channelID = channel.ID
+	}
+
+	params, err := h.buildChannelHistoryParams(buildChannelHistoryRequest{
+		ChannelID: channelID,
+		Oldest:    request.GetString("oldest", ""),
+		Latest:    request.GetString("latest", ""),
+		Limit:     request.GetInt("limit", 100),
+		Cursor:    request.GetString("cursor", ""),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	history, err := client.GetConversationHistory(ctx, params)
+	if err != nil {
+		return mcp.NewToolResultError(mapHistoryError(err, params.ChannelID).Error()), nil
+	}
+
+	workspaceURL := h.lookupWorkspaceURL(ctx, client)
+
+	response := h.convertToChannelHistoryResponse(history, workspaceURL, params.ChannelID)
+	response.Channel = channel
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+type buildChannelHistoryRequest struct {
+	ChannelID string
+	Oldest    string
+	Latest    string
+	Limit     int
+	Cursor    string
+}
+
+func (h *Handler) buildChannelHistoryParams(request buildChannelHistoryRequest) (*slack.GetConversationHistoryParameters, error) {
+	if request.ChannelID == "" {
+		return nil, fmt.Errorf("channel_id is required")
+	}
+	if !channelIDPattern.MatchString(request.ChannelID) {
+		return nil, fmt.Errorf("invalid channel ID format. Must start with 'C', 'G' or 'D' (e.g., 'C1234567')")
+	}
+
+	tsPattern := regexp.MustCompile(`^\d{10}\.\d{6}$`)
+	if request.Oldest != "" && !tsPattern.MatchString(request.Oldest) {
+		return nil, fmt.Errorf("oldest must be in format '1234567890.123456'")
+	}
+	if request.Latest != "" && !tsPattern.MatchString(request.Latest) {
+		return nil, fmt.Errorf("latest must be in format '1234567890.123456'")
+	}
+
+	if request.Limit < 1 || request.Limit > 1000 {
+		return nil, fmt.Errorf("limit must be between 1 and 1000, got %d", request.Limit)
+	}
+
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: request.ChannelID,
+		Oldest:    request.Oldest,
+		Latest:    request.Latest,
+		Limit:     request.Limit,
+	}
+	if request.Cursor != "" {
+		params.Cursor = request.Cursor
+	}
+
+	return params, nil
+}
+
+func (h *Handler) convertToChannelHistoryResponse(history *slack.GetConversationHistoryResponse, workspaceURL string, channelID string) *GetChannelHistoryResponse {
+	response := &GetChannelHistoryResponse{
+		Messages: make([]ThreadMessage, 0, len(history.Messages)),
+		HasMore:  history.HasMore,
+	}
+
+	if history.ResponseMetaData.NextCursor != "" {
+		response.NextCursor = history.ResponseMetaData.NextCursor
+	}
+
+	for _, msg := range history.Messages {
+		response.Messages = append(response.Messages, h.convertToThreadMessage(msg, workspaceURL, channelID))
+	}
+
+	return response
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetChannelHistory(t *testing.T) {
+	t.Run("can get channel history with messages", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		history := &slack.GetConversationHistoryResponse{
+			HasMore: true,
+			Messages: []slack.Message{
+				{
+					Msg: slack.Msg{
+						User:      "U2345678",
+						Text:      "Latest message",
+						Timestamp: "1234567892.123456",
+						Attachments: []slack.Attachment{
+							{
+								Title:       "Example Article",
+								FromURL:     "https://example.com/article",
+								ServiceName: "Example",
+							},
+						},
+						Reactions: []slack.ItemReaction{
+							{
+								Name:  "eyes",
+								Count: 1,
+								Users: []string{"U1234567"},
+							},
+						},
+					},
+				},
+				{
+					Msg: slack.Msg{
+						User:       "U1234567",
+						Text:       "Thread parent",
+						Timestamp:  "1234567890.123456",
+						ReplyCount: 3,
+						ReplyUsers: []string{"U2345678"},
+					},
+				},
+			},
+		}
+		history.ResponseMetaData.NextCursor = "bmV4dF90czoxMjM0NTY3ODkw"
+
+		expectedParams := &slack.GetConversationHistoryParameters{
+			ChannelID: "C1234567",
+			Oldest:    "1234567800.000000",
+			Latest:    "1234567900.000000",
+			Limit:     2,
+		}
+		mockClient.On("GetConversationHistory", mock.Anything, expectedParams).Return(history, nil)
+
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
+
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_channel_history",
+				Arguments: map[string]interface{}{
+					"channel_id": "C1234567",
+					"oldest":     "1234567800.000000",
+					"latest":     "1234567900.000000",
+					"limit":      2,
+				},
+			},
+		}
+
+		res, err := handler.GetChannelHistory(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		channel := response["channel"].(map[string]interface{})
+		assert.Equal(t, "C1234567", channel["id"])
+		assert.Equal(t, "general", channel["name"])
+
+		messages := response["messages"].([]interface{})
+		assert.Equal(t, 2, len(messages))
+
+		firstMsg := messages[0].(map[string]interface{})
+		assert.Equal(t, "U2345678", firstMsg["user"])
+		assert.Equal(t, "Latest message", firstMsg["text"])
+		assert.Equal(t, "1234567892.123456", firstMsg["ts"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567892123456", firstMsg["permalink"])
+		assert.NotContains(t, firstMsg, "reply_count")
+
+		attachments := firstMsg["attachments"].([]interface{})
+		assert.Len(t, attachments, 1)
+		att := attachments[0].(map[string]interface{})
+		assert.Equal(t, "Example Article", att["title"])
+		assert.NotContains(t, att, "service_name")
+
+		reactions := firstMsg["reactions"].([]interface{})
+		assert.Len(t, reactions, 1)
+		assert.Equal(t, "eyes", reactions[0].(map[string]interface{})["name"])
+
+		secondMsg := messages[1].(map[string]interface{})
+		assert.Equal(t, "Thread parent", secondMsg["text"])
+		assert.Equal(t, float64(3), secondMsg["reply_count"])
+		assert.Equal(t, []interface{}{"U2345678"}, secondMsg["reply_users"])
+
+		assert.Equal(t, true, response["has_more"])
+		assert.Equal(t, "bmV4dF90czoxMjM0NTY3ODkw", response["next_cursor"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns error when API call fails", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		expectedParams := &slack.GetConversationHistoryParameters{
+			ChannelID: "C1234567",
+			Limit:     100,
+		}
+		mockClient.On("GetConversationHistory", mock.Anything, expectedParams).Return(nil, errors.New("channel not found: channel_not_found"))
+
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_channel_history",
+				Arguments: map[string]interface{}{
+					"channel_id": "C1234567",
+				},
+			},
+		}
+
+		res, err := handler.GetChannelHistory(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "channel not found: channel_not_found", res.Content[0].(mcp.TextContent).Text)
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestHandler_buildChannelHistoryParams(t *testing.T) {
+	handler := &Handler{}
+
+	t.Run("all parameters specified", func(t *testing.T) {
+		request := buildChannelHistoryRequest{
+			ChannelID: "C1234567",
+			Oldest:    "1234567800.000000",
+			Latest:    "1234567900.000000",
+			Limit:     50,
+			Cursor:    "dXNlcjpVMDYxTkZUVDI=",
+		}
+
+		params, err := handler.buildChannelHistoryParams(request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &slack.GetConversationHistoryParameters{
+			ChannelID: "C1234567",
+			Oldest:    "1234567800.000000",
+			Latest:    "1234567900.000000",
+			Limit:     50,
+			Cursor:    "dXNlcjpVMDYxTkZUVDI=",
+		}, params)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			request   buildChannelHistoryRequest
+			expectErr string
+		}{
+			{
+				"empty channel_id",
+				buildChannelHistoryRequest{ChannelID: "", Limit: 100},
+				"channel_id is required",
+			},
+			{
+				"invalid channel_id format",
+				buildChannelHistoryRequest{ChannelID: "invalid123", Limit: 100},
+				"invalid channel ID format. Must start with 'C', 'G' or 'D' (e.g., 'C1234567')",
+			},
+			{
+				"invalid oldest format",
+				buildChannelHistoryRequest{ChannelID: "C1234567", Oldest: "2024-01-01", Limit: 100},
+				"oldest must be in format '1234567890.123456'",
+			},
+			{
+				"invalid latest format",
+				buildChannelHistoryRequest{ChannelID: "C1234567", Latest: "1234567890", Limit: 100},
+				"latest must be in format '1234567890.123456'",
+			},
+			{
+				"limit too low",
+				buildChannelHistoryRequest{ChannelID: "C1234567", Limit: 0},
+				"limit must be between 1 and 1000, got 0",
+			},
+			{
+				"limit too high",
+				buildChannelHistoryRequest{ChannelID: "C1234567", Limit: 1001},
+				"limit must be between 1 and 1000, got 1001",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := handler.buildChannelHistoryParams(tc.request)
+
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectErr, err.Error())
+			})
+		}
+	})
+}
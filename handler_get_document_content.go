@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/document"
+)
+
+const (
+	// maxDocumentBytes caps the size of a file downloaded by get_document_content
+	maxDocumentBytes = 20 << 20
+	// defaultDocumentMaxChars and maxDocumentMaxChars bound the extracted text returned in one call
+	defaultDocumentMaxChars = 50000
+	maxDocumentMaxChars     = 200000
+)
+
+// documentPartTypes names the unit of Part for each format
+var documentPartTypes = map[document.Format]string{
+	document.FormatPDF:  "page",
+	document.FormatDOCX: "document",
+	document.FormatXLSX: "sheet",
+	document.FormatPPTX: "slide",
+}
+
+// GetDocumentContentResponse represents the output for get_document_content tool
+type GetDocumentContentResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Filetype string `json:"filetype"`
+	// PartType is "page" for PDF, "sheet" for XLSX, "slide" for PPTX and "document" for DOCX
+	PartType   string         `json:"part_type"`
+	Parts      []DocumentPart `json:"parts"`
+	TotalParts int            `json:"total_parts"`
+	// Truncated is set when max_chars cut the text. The last returned part may be partial.
+	Truncated bool   `json:"truncated,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// DocumentPart is the text of a page, sheet or slide
+type DocumentPart struct {
+	Number int `json:"number"`
+	// Name is the sheet name for XLSX
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
+
+// GetDocumentContent extracts the text of a PDF, DOCX, XLSX or PPTX file
+func (h *Handler) GetDocumentContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	fileID := request.GetString("file_id", "")
+	if fileID == "" {
+		return mcp.NewToolResultError("file_id is required"), nil
+	}
+	if !strings.HasPrefix(fileID, "F") {
+		return mcp.NewToolResultError("invalid file ID format. Must start with 'F' (e.g., 'F1234567')"), nil
+	}
+
+	pages := request.GetString("pages", "")
+	sheets := request.GetStringSlice("sheets", nil)
+	if pages != "" && len(sheets) > 0 {
+		return mcp.NewToolResultError("pages and sheets cannot be used together"), nil
+	}
+	var pageNumbers []int
+	if pages != "" {
+		if pageNumbers, err = parsePageSelection(pages); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	maxChars := request.GetInt("max_chars", defaultDocumentMaxChars)
+	if maxChars < 1 || maxChars > maxDocumentMaxChars {
+		return mcp.NewToolResultError(fmt.Sprintf("max_chars must be between 1 and %d, got %d", maxDocumentMaxChars, maxChars)), nil
+	}
+
+	fileInfo, err := client.GetFileInfo(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get file info: %v", err)), nil
+	}
+
+	format, ok := document.DetectFormat(fileInfo.Filetype, fileInfo.Mimetype)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("file %s is not a supported document (filetype: %s). Supported types are pdf, docx, xlsx and pptx", fileID, fileInfo.Filetype)), nil
+	}
+	if pages != "" && format != document.FormatPDF && format != document.FormatPPTX {
+		return mcp.NewToolResultError("pages can only be used with pdf and pptx files"), nil
+	}
+	if len(sheets) > 0 && format != document.FormatXLSX {
+		return mcp.NewToolResultError("sheets can only be used with xlsx files"), nil
+	}
+
+	downloadURL := fileInfo.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = fileInfo.URLPrivate
+	}
+	if downloadURL == "" {
+		return mcp.NewToolResultError("file has no download URL"), nil
+	}
+
+	if int64(fileInfo.Size) > maxDocumentBytes {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download file: %v", &downloadTooLargeError{limit: maxDocumentBytes})), nil
+	}
+
+	data, err := downloadFile(ctx, client, downloadURL, maxDocumentBytes)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download file: %v", err)), nil
+	}
+
+	// Only the selected parts are decoded, so asking for one page of a large PDF stays cheap
+	var include document.PartFilter
+	switch {
+	case len(pageNumbers) > 0:
+		include = func(part document.Part) bool { return slices.Contains(pageNumbers, part.Number) }
+	case len(sheets) > 0:
+		include = func(part document.Part) bool { return slices.Contains(sheets, part.Name) }
+	}
+
+	parts, err := document.Extract(data, format, include)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to extract text: %v", err)), nil
+	}
+
+	if last := len(pageNumbers); last > 0 && pageNumbers[last-1] > len(parts) {
+		return mcp.NewToolResultError(fmt.Sprintf("page %d is out of range: the document has %d pages", pageNumbers[last-1], len(parts))), nil
+	}
+	if len(sheets) > 0 {
+		if err := checkSheetsExist(parts, sheets); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	selected := parts
+	if include != nil {
+		selected = make([]document.Part, 0, len(parts))
+		for _, part := range parts {
+			if include(part) {
+				selected = append(selected, part)
+			}
+		}
+	}
+
+	response := GetDocumentContentResponse{
+		ID:         fileID,
+		Name:       fileInfo.Name,
+		Title:      fileInfo.Title,
+		Filetype:   fileInfo.Filetype,
+		PartType:   documentPartTypes[format],
+		Parts:      []DocumentPart{},
+		TotalParts: len(parts),
+		Permalink:  fileInfo.Permalink,
+	}
+
+	remaining := maxChars
+	for _, part := range selected {
+		if remaining == 0 {
+			response.Truncated = true
+			break
+		}
+		text := part.Text
+		if runes := []rune(text); len(runes) > remaining {
+			text = string(runes[:remaining])
+			response.Truncated = true
+		}
+		remaining -= len([]rune(text))
+		response.Parts = append(response.Parts, DocumentPart{
+			Number: part.Number,
+			Name:   part.Name,
+			Text:   text,
+		})
+	}
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// parsePageSelection parses a selection such as "1-3,5" into sorted, unique 1-based numbers
+func parsePageSelection(spec string) ([]int, error) {
+	seen := map[int]bool{}
+	var numbers []int
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		first, last, isRange := strings.Cut(item, "-")
+
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pages '%s': use page numbers and ranges like '1-3,5'", spec)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil {
+				return nil, fmt.Errorf("invalid pages '%s': use page numbers and ranges like '1-3,5'", spec)
+			}
+		}
+		if start < 1 || end < start {
+			return nil, fmt.Errorf("invalid pages '%s': page numbers start at 1 and ranges must be ascending", spec)
+		}
+
+		for n := start; n <= end; n++ {
+			if !seen[n] {
+				seen[n] = true
+				numbers = append(numbers, n)
+			}
+		}
+	}
+	slices.Sort(numbers)
+	return numbers, nil
+}
+
+// checkSheetsExist returns an error listing the available sheets when any of the names is not a sheet of the workbook
+func checkSheetsExist(parts []document.Part, names []string) error {
+	available := make([]string, len(parts))
+	for i, part := range parts {
+		available[i] = part.Name
+	}
+	for _, name := range names {
+		if !slices.Contains(available, name) {
+			return fmt.Errorf("sheet not found: '%s'. Available sheets: %s", name, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
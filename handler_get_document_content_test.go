@@ -0,0 +1,292 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// buildTestPDF creates a PDF with one Helvetica text line per page
+func buildTestPDF(pageTexts ...string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // page tree, filled in below
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	var kids []string
+	for _, text := range pageTexts {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>", len(objects)))
+		kids = append(kids, fmt.Sprintf("%d 0 R", len(objects)))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /Resources << /Font << /F1 3 0 R >> >> >>", strings.Join(kids, " "), len(kids))
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+// buildTestXLSX creates a workbook with one cell per sheet
+func buildTestXLSX(t *testing.T, sheets map[string]string, order []string) []byte {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	write := func(name, content string) {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+
+	var sheetElements, rels strings.Builder
+	for i, name := range order {
+		fmt.Fprintf(&sheetElements, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i+1, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+		write(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), fmt.Sprintf(`<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>%s</t></is></c></row></sheetData></worksheet>`, sheets[name]))
+	}
+	write("xl/workbook.xml", `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`+sheetElements.String()+`</sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", `<Relationships>`+rels.String()+`</Relationships>`)
+	assert.NoError(t, zw.Close())
+	return b.Bytes()
+}
+
+func TestHandler_GetDocumentContent(t *testing.T) {
+	newHandler := func(file *slack.File, content []byte) (*Handler, *SlackClientMock) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, file.ID).Return(file, nil)
+		mockClient.On("GetFile", mock.Anything, file.URLPrivateDownload, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(io.Writer).Write(content)
+		}).Return(nil)
+
+		return &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}, mockClient
+	}
+
+	newRequest := func(args map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name:      "get_document_content",
+				Arguments: args,
+			},
+		}
+	}
+
+	pdfFile := &slack.File{
+		ID:                 "F1234567",
+		Name:               "spec.pdf",
+		Title:              "Spec",
+		Filetype:           "pdf",
+		Mimetype:           "application/pdf",
+		URLPrivateDownload: "https://files.slack.com/F1234567/spec.pdf",
+		Permalink:          "https://workspace.slack.com/files/U123/F1234567/spec.pdf",
+	}
+
+	t.Run("extracts the selected pages of a PDF", func(t *testing.T) {
+		handler, mockClient := newHandler(pdfFile, buildTestPDF("Overview", "Requirements", "Appendix"))
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+			"pages":   "3,2",
+		}))
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "F1234567", response["id"])
+		assert.Equal(t, "spec.pdf", response["name"])
+		assert.Equal(t, "pdf", response["filetype"])
+		assert.Equal(t, "page", response["part_type"])
+		assert.Equal(t, float64(3), response["total_parts"])
+		assert.NotContains(t, response, "truncated")
+		assert.Equal(t, "https://workspace.slack.com/files/U123/F1234567/spec.pdf", response["permalink"])
+
+		parts := response["parts"].([]interface{})
+		assert.Equal(t, 2, len(parts))
+		assert.Equal(t, float64(2), parts[0].(map[string]interface{})["number"])
+		assert.Equal(t, "Requirements", parts[0].(map[string]interface{})["text"])
+		assert.Equal(t, float64(3), parts[1].(map[string]interface{})["number"])
+		assert.Equal(t, "Appendix", parts[1].(map[string]interface{})["text"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("truncates text at max_chars", func(t *testing.T) {
+		handler, _ := newHandler(pdfFile, buildTestPDF("Overview", "Requirements", "Appendix"))
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id":   "F1234567",
+			"max_chars": 12,
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, true, response["truncated"])
+		parts := response["parts"].([]interface{})
+		assert.Equal(t, 2, len(parts))
+		assert.Equal(t, "Overview", parts[0].(map[string]interface{})["text"])
+		assert.Equal(t, "Requ", parts[1].(map[string]interface{})["text"])
+	})
+
+	t.Run("extracts the selected sheets of an XLSX", func(t *testing.T) {
+		xlsxFile := &slack.File{
+			ID:                 "F2345678",
+			Name:               "budget.xlsx",
+			Filetype:           "xlsx",
+			URLPrivateDownload: "https://files.slack.com/F2345678/budget.xlsx",
+		}
+		data := buildTestXLSX(t, map[string]string{"2024": "old", "2025": "new"}, []string{"2024", "2025"})
+		handler, _ := newHandler(xlsxFile, data)
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F2345678",
+			"sheets":  []string{"2025"},
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "sheet", response["part_type"])
+		assert.Equal(t, float64(2), response["total_parts"])
+		parts := response["parts"].([]interface{})
+		assert.Equal(t, 1, len(parts))
+		assert.Equal(t, float64(2), parts[0].(map[string]interface{})["number"])
+		assert.Equal(t, "2025", parts[0].(map[string]interface{})["name"])
+		assert.Equal(t, "new", parts[0].(map[string]interface{})["text"])
+
+		res, err = handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F2345678",
+			"sheets":  []string{"2026"},
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "sheet not found: '2026'. Available sheets: 2024, 2025", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error for page out of range", func(t *testing.T) {
+		handler, _ := newHandler(pdfFile, buildTestPDF("Only page"))
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+			"pages":   "1-2",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "page 2 is out of range: the document has 1 pages", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error for unsupported files without downloading", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F3456789").Return(&slack.File{
+			ID:       "F3456789",
+			Filetype: "png",
+			Mimetype: "image/png",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F3456789",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "file F3456789 is not a supported document (filetype: png). Supported types are pdf, docx, xlsx and pptx", res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns error when the document cannot be parsed", func(t *testing.T) {
+		handler, _ := newHandler(pdfFile, []byte("not really a pdf"))
+
+		res, err := handler.GetDocumentContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "failed to extract text: not a PDF file", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("validates parameters", func(t *testing.T) {
+		handler, _ := newHandler(pdfFile, nil)
+
+		tests := []struct {
+			name     string
+			args     map[string]interface{}
+			expected string
+		}{
+			{"missing file_id", map[string]interface{}{}, "file_id is required"},
+			{"invalid file_id", map[string]interface{}{"file_id": "C1234567"}, "invalid file ID format. Must start with 'F' (e.g., 'F1234567')"},
+			{"pages with sheets", map[string]interface{}{"file_id": "F1234567", "pages": "1", "sheets": []string{"a"}}, "pages and sheets cannot be used together"},
+			{"max_chars out of range", map[string]interface{}{"file_id": "F1234567", "max_chars": 0}, "max_chars must be between 1 and 200000, got 0"},
+			{"sheets with a PDF", map[string]interface{}{"file_id": "F1234567", "sheets": []string{"a"}}, "sheets can only be used with xlsx files"},
+			{"invalid pages", map[string]interface{}{"file_id": "F1234567", "pages": "1-"}, "invalid pages '1-': use page numbers and ranges like '1-3,5'"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := handler.GetDocumentContent(t.Context(), newRequest(tt.args))
+				assert.NoError(t, err)
+				assert.True(t, res.IsError)
+				assert.Equal(t, tt.expected, res.Content[0].(mcp.TextContent).Text)
+			})
+		}
+	})
+}
+
+func TestParsePageSelection(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expected    []int
+		expectedErr string
+	}{
+		{"single page", "2", []int{2}, ""},
+		{"ranges and pages are sorted and deduplicated", "4-5, 1,2-4", []int{1, 2, 3, 4, 5}, ""},
+		{"not a number", "first", nil, "invalid pages 'first': use page numbers and ranges like '1-3,5'"},
+		{"descending range", "3-1", nil, "invalid pages '3-1': page numbers start at 1 and ranges must be ascending"},
+		{"zero", "0", nil, "invalid pages '0': page numbers start at 1 and ranges must be ascending"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers, err := parsePageSelection(tt.spec)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/document"
+)
+
+const (
+	// maxFileContentBytes caps the size of a file downloaded by get_file_content
+	maxFileContentBytes = 5 << 20
+	// defaultFileContentLimit and maxFileContentLimit bound the number of lines returned in one call
+	defaultFileContentLimit = 1000
+	maxFileContentLimit     = 10000
+	// defaultFileContentMaxChars and maxFileContentMaxChars bound the characters returned in one call
+	defaultFileContentMaxChars = 50000
+	maxFileContentMaxChars     = 200000
+)
+
+// GetFileContentResponse represents the output for get_file_content tool
+type GetFileContentResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Filetype  string `json:"filetype,omitempty"`
+	Mimetype  string `json:"mimetype,omitempty"`
+	Encoding  string `json:"encoding"`
+	Content   string `json:"content"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	// TotalLines lets callers request the next slice of a long file
+	TotalLines int `json:"total_lines"`
+	// Truncated is set when limit or max_chars stopped before the requested end_line. The last returned line may be partial.
+	Truncated bool   `json:"truncated,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// GetFileContent retrieves the content of a text-like file such as a snippet, post, CSV or plain text file
+func (h *Handler) GetFileContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	fileID := request.GetString("file_id", "")
+	if fileID == "" {
+		return mcp.NewToolResultError("file_id is required"), nil
+	}
+	if !strings.HasPrefix(fileID, "F") {
+		return mcp.NewToolResultError("invalid file ID format. Must start with 'F' (e.g., 'F1234567')"), nil
+	}
+
+	startLine := request.GetInt("start_line", 1)
+	if startLine < 1 {
+		return mcp.NewToolResultError(fmt.Sprintf("start_line must be 1 or greater, got %d", startLine)), nil
+	}
+	// 0 means the last line
+	endLine := request.GetInt("end_line", 0)
+	if endLine != 0 && endLine < startLine {
+		return mcp.NewToolResultError(fmt.Sprintf("end_line must be greater than or equal to start_line, got start_line=%d, end_line=%d", startLine, endLine)), nil
+	}
+	limit := request.GetInt("limit", defaultFileContentLimit)
+	if limit < 1 || limit > maxFileContentLimit {
+		return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d, got %d", maxFileContentLimit, limit)), nil
+	}
+	maxChars := request.GetInt("max_chars", defaultFileContentMaxChars)
+	if maxChars < 1 || maxChars > maxFileContentMaxChars {
+		return mcp.NewToolResultError(fmt.Sprintf("max_chars must be between 1 and %d, got %d", maxFileContentMaxChars, maxChars)), nil
+	}
+
+	fileInfo, err := client.GetFileInfo(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get file info: %v", err)), nil
+	}
+
+	if isCanvasFile(fileInfo) {
+		return mcp.NewToolResultError(fmt.Sprintf("file %s is a canvas. Use get_canvas_content instead", fileID)), nil
+	}
+	if format, ok := document.DetectFormat(fileInfo.Filetype, fileInfo.Mimetype); ok {
+		return mcp.NewToolResultError(fmt.Sprintf("file %s is a %s document. Use get_document_content instead", fileID, format)), nil
+	}
+	if !isTextFile(fileInfo) {
+		return mcp.NewToolResultError(fmt.Sprintf("file %s is a binary file (filetype: %s, mimetype: %s) and cannot be returned as text", fileID, fileInfo.Filetype, fileInfo.Mimetype)), nil
+	}
+
+	downloadURL := fileInfo.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = fileInfo.URLPrivate
+	}
+	if downloadURL == "" {
+		return mcp.NewToolResultError("file has no download URL"), nil
+	}
+
+	if int64(fileInfo.Size) > maxFileContentBytes {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download file: %v", &downloadTooLargeError{limit: maxFileContentBytes})), nil
+	}
+
+	data, err := downloadFile(ctx, client, downloadURL, maxFileContentBytes)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download file: %v", err)), nil
+	}
+
+	text, encoding := decodeText(data, fileInfo.Mimetype)
+	if looksBinary(text) {
+		return mcp.NewToolResultError(fmt.Sprintf("file %s contains binary data and cannot be returned as text", fileID)), nil
+	}
+
+	lines := splitLines(text)
+	if len(lines) > 0 && startLine > len(lines) {
+		return mcp.NewToolResultError(fmt.Sprintf("start_line %d exceeds the number of lines in the file (%d)", startLine, len(lines))), nil
+	}
+	if endLine == 0 || endLine > len(lines) {
+		endLine = len(lines)
+	}
+
+	var content string
+	var truncated bool
+	if len(lines) > 0 {
+		var returned int
+		content, returned, truncated = joinLinesWithin(lines[startLine-1:endLine], limit, maxChars)
+		endLine = startLine + returned - 1
+	} else {
+		startLine = 0
+	}
+
+	response := GetFileContentResponse{
+		ID:         fileID,
+		Name:       fileInfo.Name,
+		Title:      fileInfo.Title,
+		Filetype:   fileInfo.Filetype,
+		Mimetype:   fileInfo.Mimetype,
+		Encoding:   encoding,
+		Content:    content,
+		StartLine:  startLine,
+		EndLine:    endLine,
+		TotalLines: len(lines),
+		Truncated:  truncated,
+		Permalink:  fileInfo.Permalink,
+	}
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetFileContent(t *testing.T) {
+	newRequest := func(args map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name:      "get_file_content",
+				Arguments: args,
+			},
+		}
+	}
+
+	newHandler := func(file *slack.File, content string) (*Handler, *SlackClientMock) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, file.ID).Return(file, nil)
+		mockClient.On("GetFile", mock.Anything, file.URLPrivateDownload, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(io.Writer).Write([]byte(content))
+		}).Return(nil)
+
+		return &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}, mockClient
+	}
+
+	csvFile := &slack.File{
+		ID:                 "F1234567",
+		Name:               "data.csv",
+		Title:              "data.csv",
+		Mode:               "hosted",
+		Filetype:           "csv",
+		Mimetype:           "text/csv",
+		URLPrivateDownload: "https://files.slack.com/F1234567/data.csv",
+		Permalink:          "https://workspace.slack.com/files/U123/F1234567/data.csv",
+	}
+
+	t.Run("returns the whole text file", func(t *testing.T) {
+		handler, mockClient := newHandler(csvFile, "id,name\n1,alice\n2,bob\n")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+		}))
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "F1234567", response["id"])
+		assert.Equal(t, "data.csv", response["name"])
+		assert.Equal(t, "csv", response["filetype"])
+		assert.Equal(t, "utf-8", response["encoding"])
+		assert.Equal(t, "id,name\n1,alice\n2,bob", response["content"])
+		assert.Equal(t, float64(1), response["start_line"])
+		assert.Equal(t, float64(3), response["end_line"])
+		assert.Equal(t, float64(3), response["total_lines"])
+		assert.NotContains(t, response, "truncated")
+		assert.Equal(t, "https://workspace.slack.com/files/U123/F1234567/data.csv", response["permalink"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns the requested line range", func(t *testing.T) {
+		handler, _ := newHandler(csvFile, "id,name\n1,alice\n2,bob\n3,carol\n")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id":    "F1234567",
+			"start_line": 2,
+			"end_line":   3,
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "1,alice\n2,bob", response["content"])
+		assert.Equal(t, float64(2), response["start_line"])
+		assert.Equal(t, float64(3), response["end_line"])
+		assert.Equal(t, float64(4), response["total_lines"])
+	})
+
+	t.Run("caps the output with limit and reports truncated", func(t *testing.T) {
+		handler, _ := newHandler(csvFile, "id,name\n1,alice\n2,bob\n3,carol\n")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id":    "F1234567",
+			"start_line": 2,
+			"limit":      2,
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "1,alice\n2,bob", response["content"])
+		assert.Equal(t, float64(2), response["start_line"])
+		assert.Equal(t, float64(3), response["end_line"])
+		assert.Equal(t, float64(4), response["total_lines"])
+		assert.Equal(t, true, response["truncated"])
+	})
+
+	t.Run("caps the output with max_chars and reports the last returned line", func(t *testing.T) {
+		handler, _ := newHandler(csvFile, "id,name\n1,alice\n2,bob\n")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id":   "F1234567",
+			"max_chars": 10,
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "id,name\n1,", response["content"])
+		assert.Equal(t, float64(1), response["start_line"])
+		assert.Equal(t, float64(2), response["end_line"])
+		assert.Equal(t, float64(3), response["total_lines"])
+		assert.Equal(t, true, response["truncated"])
+	})
+
+	t.Run("returns error when start_line is beyond the end of the file", func(t *testing.T) {
+		handler, _ := newHandler(csvFile, "id,name\n1,alice\n")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id":    "F1234567",
+			"start_line": 5,
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "start_line 5 exceeds the number of lines in the file (2)", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error for binary file types without downloading", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F2345678").Return(&slack.File{
+			ID:                 "F2345678",
+			Mode:               "hosted",
+			Filetype:           "png",
+			Mimetype:           "image/png",
+			URLPrivateDownload: "https://files.slack.com/F2345678/image.png",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F2345678",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "file F2345678 is a binary file (filetype: png, mimetype: image/png) and cannot be returned as text", res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns error when the downloaded content is binary", func(t *testing.T) {
+		handler, _ := newHandler(csvFile, "id\x00\x01\x02")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "file F1234567 contains binary data and cannot be returned as text", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("points documents to get_document_content", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F4567890").Return(&slack.File{
+			ID:       "F4567890",
+			Mode:     "hosted",
+			Filetype: "pdf",
+			Mimetype: "application/pdf",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F4567890",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "file F4567890 is a pdf document. Use get_document_content instead", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("points canvases to get_canvas_content", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F3456789").Return(&slack.File{
+			ID:       "F3456789",
+			Mode:     "canvas",
+			Filetype: "quip",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F3456789",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "file F3456789 is a canvas. Use get_canvas_content instead", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("rejects files larger than the download limit", func(t *testing.T) {
+		largeFile := *csvFile
+		largeFile.Size = maxFileContentBytes + 1
+		handler, mockClient := newHandler(&largeFile, "")
+
+		res, err := handler.GetFileContent(t.Context(), newRequest(map[string]interface{}{
+			"file_id": "F1234567",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "failed to download file: file exceeds the download limit of 5242880 bytes", res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("validates parameters", func(t *testing.T) {
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return &SlackClientMock{}, nil
+			},
+		}
+
+		tests := []struct {
+			name     string
+			args     map[string]interface{}
+			expected string
+		}{
+			{"missing file_id", map[string]interface{}{}, "file_id is required"},
+			{"invalid file_id", map[string]interface{}{"file_id": "C1234567"}, "invalid file ID format. Must start with 'F' (e.g., 'F1234567')"},
+			{"start_line below 1", map[string]interface{}{"file_id": "F1234567", "start_line": 0}, "start_line must be 1 or greater, got 0"},
+			{"end_line before start_line", map[string]interface{}{"file_id": "F1234567", "start_line": 5, "end_line": 3}, "end_line must be greater than or equal to start_line, got start_line=5, end_line=3"},
+			{"limit below 1", map[string]interface{}{"file_id": "F1234567", "limit": 0}, "limit must be between 1 and 10000, got 0"},
+			{"max_chars above the maximum", map[string]interface{}{"file_id": "F1234567", "max_chars": 200001}, "max_chars must be between 1 and 200000, got 200001"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := handler.GetFileContent(t.Context(), newRequest(tt.args))
+				assert.NoError(t, err)
+				assert.True(t, res.IsError)
+				assert.Equal(t, tt.expected, res.Content[0].(mcp.TextContent).Text)
+			})
+		}
+	})
+}
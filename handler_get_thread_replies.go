@@ -5,24 +5,39 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/permalink"
 	"github.com/slack-go/slack"
 )
 
+const (
+	// fetchAllPageSize is the page size used while following cursors in fetch_all mode
+	fetchAllPageSize = 1000
+	// maxFetchAllMessages is the hard upper bound for max_messages
+	maxFetchAllMessages = 10000
+)
+
 // GetThreadRepliesResponse represents the response structure for get_thread_replies
 type GetThreadRepliesResponse struct {
+	Channel    *ChannelInfo    `json:"channel,omitempty"`
 	Messages   []ThreadMessage `json:"messages"`
 	HasMore    bool            `json:"has_more"`
 	NextCursor string          `json:"next_cursor,omitempty"`
+	// Truncated is true when fetch_all stopped at max_messages before reaching the end of the thread
+	Truncated bool `json:"truncated,omitempty"`
+	// Mentions maps each raw ID to its resolved mention. Set only when resolve_mentions is enabled.
+	Mentions map[string]string `json:"mentions,omitempty"`
 }
 
 type ThreadMessage struct {
 	User        string           `json:"user"`
+	UserName    string           `json:"user_name,omitempty"`
+	RealName    string           `json:"real_name,omitempty"`
 	Text        string           `json:"text"`
 	Attachments []AttachmentInfo `json:"attachments,omitempty"`
 	Timestamp   string           `json:"ts"`
+	Permalink   string           `json:"permalink,omitempty"`
 	ReplyCount  int              `json:"reply_count,omitempty"`
 	ReplyUsers  []string         `json:"reply_users,omitempty"`
 	Reactions   []Reaction       `json:"reactions,omitempty"`
@@ -41,8 +56,18 @@ func (h *Handler) GetThreadReplies(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
 	}
 
+	channelID := request.GetString("channel_id", "")
+	var channel *ChannelInfo
+	if channelID != "" {
+		channel, err = h.resolveChannelID(ctx, client, channelID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		channelID = channel.ID
+	}
+
 	params, err := h.buildThreadRepliesParams(buildThreadRepliesRequest{
-		ChannelID: request.GetString("channel_id", ""),
+		ChannelID: channelID,
 		ThreadTS:  request.GetString("thread_ts", ""),
 		Limit:     request.GetInt("limit", 100),
 		Cursor:    request.GetString("cursor", ""),
@@ -51,12 +76,50 @@ func (h *Handler) GetThreadReplies(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	messages, hasMore, nextCursor, err := client.GetConversationReplies(params)
+	fetchAll := request.GetBool("fetch_all", false)
+	maxMessages := request.GetInt("max_messages", 1000)
+	if fetchAll && (maxMessages < 1 || maxMessages > maxFetchAllMessages) {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d, got %d", maxFetchAllMessages, maxMessages)), nil
+	}
+
+	var messages []slack.Message
+	var hasMore, truncated bool
+	var nextCursor string
+	if fetchAll {
+		messages, hasMore, nextCursor, truncated, err = fetchAllThreadReplies(ctx, client, params, maxMessages)
+	} else {
+		messages, hasMore, nextCursor, err = client.GetConversationReplies(ctx, params)
+	}
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(mapHistoryError(err, params.ChannelID).Error()), nil
 	}
 
-	response := h.convertToThreadResponse(messages, hasMore, nextCursor)
+	workspaceURL := h.lookupWorkspaceURL(ctx, client)
+
+	response := h.convertToThreadResponse(messages, hasMore, nextCursor, workspaceURL, params.ChannelID)
+	response.Channel = channel
+	response.Truncated = truncated
+
+	if request.GetBool("resolve_mentions", false) {
+		texts := make([]*string, 0, len(response.Messages))
+		for i := range response.Messages {
+			texts = append(texts, &response.Messages[i].Text)
+		}
+		response.Mentions = h.resolveMentions(ctx, client, texts)
+	}
+
+	if request.GetBool("include_user_info", false) {
+		userIDs := make([]string, 0, len(response.Messages))
+		for _, msg := range response.Messages {
+			userIDs = append(userIDs, msg.User)
+		}
+		userInfo := h.lookupMessageUserInfo(ctx, client, userIDs)
+		for i := range response.Messages {
+			info := userInfo[response.Messages[i].User]
+			response.Messages[i].UserName = info.UserName
+			response.Messages[i].RealName = info.RealName
+		}
+	}
 
 	jsonData, err := json.Marshal(response)
 	if err != nil {
@@ -77,8 +140,8 @@ func (h *Handler) buildThreadRepliesParams(request buildThreadRepliesRequest) (*
 	if request.ChannelID == "" {
 		return nil, fmt.Errorf("channel_id is required")
 	}
-	if !strings.HasPrefix(request.ChannelID, "C") {
-		return nil, fmt.Errorf("invalid channel ID format. Must start with 'C' (e.g., 'C1234567')")
+	if !channelIDPattern.MatchString(request.ChannelID) {
+		return nil, fmt.Errorf("invalid channel ID format. Must start with 'C', 'G' or 'D' (e.g., 'C1234567')")
 	}
 
 	if request.ThreadTS == "" {
@@ -105,7 +168,53 @@ func (h *Handler) buildThreadRepliesParams(request buildThreadRepliesRequest) (*
 	return params, nil
 }
 
-func (h *Handler) convertToThreadResponse(messages []slack.Message, hasMore bool, nextCursor string) *GetThreadRepliesResponse {
+// fetchAllThreadReplies follows cursors until the thread is exhausted or maxMessages messages have been collected.
+// The parent message is returned on every page, so messages are deduplicated by ts.
+// When the cap is hit, hasMore and nextCursor describe where a later call can resume: a page that would overflow the cap
+// is dropped whole and its own cursor is returned, so no message is skipped.
+// Only a first page holding more than maxMessages new messages is returned whole, since there is nothing to resume from.
+func fetchAllThreadReplies(ctx context.Context, client SlackClient, params *slack.GetConversationRepliesParameters, maxMessages int) ([]slack.Message, bool, string, bool, error) {
+	var all []slack.Message
+	seen := make(map[string]bool)
+	page := *params
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, false, "", false, fmt.Errorf("fetch_all was interrupted after %d messages: %w", len(all), err)
+		}
+
+		page.Limit = min(fetchAllPageSize, maxMessages-len(all))
+		messages, hasMore, nextCursor, err := client.GetConversationReplies(ctx, &page)
+		if err != nil {
+			return nil, false, "", false, err
+		}
+
+		var fresh []slack.Message
+		for _, msg := range messages {
+			if !seen[msg.Timestamp] {
+				fresh = append(fresh, msg)
+			}
+		}
+		if len(all) > 0 && len(all)+len(fresh) > maxMessages {
+			// Keeping part of the page would make its next cursor skip the rest, so resume from this page instead
+			return all, true, page.Cursor, true, nil
+		}
+		for _, msg := range fresh {
+			seen[msg.Timestamp] = true
+			all = append(all, msg)
+		}
+
+		if !hasMore || nextCursor == "" {
+			return all, false, "", false, nil
+		}
+		if len(all) >= maxMessages {
+			return all, true, nextCursor, true, nil
+		}
+		page.Cursor = nextCursor
+	}
+}
+
+func (h *Handler) convertToThreadResponse(messages []slack.Message, hasMore bool, nextCursor string, workspaceURL string, channelID string) *GetThreadRepliesResponse {
 	response := &GetThreadRepliesResponse{
 		Messages: make([]ThreadMessage, 0, len(messages)),
 		HasMore:  hasMore,
@@ -116,38 +225,44 @@ func (h *Handler) convertToThreadResponse(messages []slack.Message, hasMore bool
 	}
 
 	for _, msg := range messages {
-		threadMsg := ThreadMessage{
-			User:      msg.User,
-			Text:      msg.Text,
-			Timestamp: msg.Timestamp,
-		}
+		response.Messages = append(response.Messages, h.convertToThreadMessage(msg, workspaceURL, channelID))
+	}
 
-		if msg.ReplyCount > 0 {
-			threadMsg.ReplyCount = msg.ReplyCount
-		}
+	return response
+}
 
-		if len(msg.ReplyUsers) > 0 {
-			threadMsg.ReplyUsers = msg.ReplyUsers
-		}
+// convertToThreadMessage converts a Slack message to the ThreadMessage shape shared by thread and history responses
+func (h *Handler) convertToThreadMessage(msg slack.Message, workspaceURL string, channelID string) ThreadMessage {
+	threadMsg := ThreadMessage{
+		User:      msg.User,
+		Text:      messageText(msg.Text, msg.Blocks),
+		Timestamp: msg.Timestamp,
+		Permalink: permalink.Build(workspaceURL, channelID, msg.Timestamp, msg.ThreadTimestamp),
+	}
 
-		if len(msg.Reactions) > 0 {
-			reactions := make([]Reaction, 0, len(msg.Reactions))
-			for _, reaction := range msg.Reactions {
-				reactions = append(reactions, Reaction{
-					Name:  reaction.Name,
-					Count: reaction.Count,
-					Users: reaction.Users,
-				})
-			}
-			threadMsg.Reactions = reactions
-		}
+	if msg.ReplyCount > 0 {
+		threadMsg.ReplyCount = msg.ReplyCount
+	}
+
+	if len(msg.ReplyUsers) > 0 {
+		threadMsg.ReplyUsers = msg.ReplyUsers
+	}
 
-		if len(msg.Attachments) > 0 {
-			threadMsg.Attachments = convertAttachments(msg.Attachments)
+	if len(msg.Reactions) > 0 {
+		reactions := make([]Reaction, 0, len(msg.Reactions))
+		for _, reaction := range msg.Reactions {
+			reactions = append(reactions, Reaction{
+				Name:  reaction.Name,
+				Count: reaction.Count,
+				Users: reaction.Users,
+			})
 		}
+		threadMsg.Reactions = reactions
+	}
 
-		response.Messages = append(response.Messages, threadMsg)
+	if len(msg.Attachments) > 0 {
+		threadMsg.Attachments = convertAttachments(msg.Attachments)
 	}
 
-	return response
+	return threadMsg
 }
@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_GetThreadReplies(t *testing.T) {
@@ -17,18 +19,20 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 		messages := []slack.Message{
 			{
 				Msg: slack.Msg{
-					User:       "U1234567",
-					Text:       "Original message",
-					Timestamp:  "1234567890.123456",
-					ReplyCount: 2,
-					ReplyUsers: []string{"U2345678", "U3456789"},
+					User:            "U1234567",
+					Text:            "Original message",
+					Timestamp:       "1234567890.123456",
+					ThreadTimestamp: "1234567890.123456",
+					ReplyCount:      2,
+					ReplyUsers:      []string{"U2345678", "U3456789"},
 				},
 			},
 			{
 				Msg: slack.Msg{
-					User:      "U2345678",
-					Text:      "Reply message 1",
-					Timestamp: "1234567891.123456",
+					User:            "U2345678",
+					Text:            "Reply message 1",
+					Timestamp:       "1234567891.123456",
+					ThreadTimestamp: "1234567890.123456",
 					Attachments: []slack.Attachment{
 						{
 							Title:       "YouTube Video",
@@ -62,12 +66,23 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 			Limit:     50,
 		}
 
-		mockClient.On("GetConversationReplies", expectedParams).Return(messages, hasMore, nextCursor, nil)
+		mockClient.On("GetConversationReplies", mock.Anything, expectedParams).Return(messages, hasMore, nextCursor, nil)
 
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
+
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
 				return mockClient, nil
 			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
 		}
 
 		req := mcp.CallToolRequest{
@@ -100,6 +115,7 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 		assert.Equal(t, "U1234567", firstMsg["user"])
 		assert.Equal(t, "Original message", firstMsg["text"])
 		assert.Equal(t, "1234567890.123456", firstMsg["ts"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567890123456", firstMsg["permalink"])
 		assert.Equal(t, float64(2), firstMsg["reply_count"])
 		assert.Equal(t, []interface{}{"U2345678", "U3456789"}, firstMsg["reply_users"])
 		assert.Nil(t, firstMsg["attachments"])
@@ -108,6 +124,7 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 		assert.Equal(t, "U2345678", secondMsg["user"])
 		assert.Equal(t, "Reply message 1", secondMsg["text"])
 		assert.Equal(t, "1234567891.123456", secondMsg["ts"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567891123456?thread_ts=1234567890.123456&cid=C1234567", secondMsg["permalink"])
 
 		secondAttachments := secondMsg["attachments"].([]interface{})
 		assert.Len(t, secondAttachments, 1)
@@ -147,12 +164,23 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 			Limit:     100,
 		}
 
-		mockClient.On("GetConversationReplies", expectedParams).Return(messages, hasMore, nextCursor, nil)
+		mockClient.On("GetConversationReplies", mock.Anything, expectedParams).Return(messages, hasMore, nextCursor, nil)
+
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
 
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
 				return mockClient, nil
 			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
 		}
 
 		req := mcp.CallToolRequest{
@@ -185,6 +213,530 @@ func TestHandler_GetThreadReplies(t *testing.T) {
 
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("resolves channel name to ID", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		expectedParams := &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     100,
+		}
+		mockClient.On("GetConversationReplies", mock.Anything, expectedParams).Return([]slack.Message{}, false, "", nil)
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return([]slack.Channel{
+			newTestChannel("C1234567", "general", false, true),
+		}, "", nil)
+
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id": "#general",
+					"thread_ts":  "1234567890.123456",
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		channel := response["channel"].(map[string]interface{})
+		assert.Equal(t, "C1234567", channel["id"])
+		assert.Equal(t, "general", channel["name"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("adds author names when include_user_info is true", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		mockClient.On("GetConversationReplies", mock.Anything, mock.Anything).Return([]slack.Message{
+			{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456"}},
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, false, "", nil)
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1234567", Name: "jdoe", Profile: slack.UserProfile{DisplayName: "john", RealName: "John Doe"}},
+			{ID: "U2345678", Name: "jsmith", Profile: slack.UserProfile{RealName: "Jane Smith"}},
+		}, nil).Once()
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+			userRepository:      userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id":        "C1234567",
+					"thread_ts":         "1234567890.123456",
+					"include_user_info": true,
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		messages := response["messages"].([]interface{})
+		parent := messages[0].(map[string]interface{})
+		assert.Equal(t, "john", parent["user_name"])
+		assert.Equal(t, "John Doe", parent["real_name"])
+		reply := messages[1].(map[string]interface{})
+		assert.Equal(t, "Jane Smith", reply["user_name"])
+		assert.Equal(t, "Jane Smith", reply["real_name"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fetch_all follows cursors and merges pages", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		parent := slack.Message{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 3}}
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     1000,
+		}).Return([]slack.Message{
+			parent,
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 1", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, true, "cursor-2", nil).Once()
+		// Slack returns the parent again on every page
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Cursor:    "cursor-2",
+			Limit:     998,
+		}).Return([]slack.Message{
+			parent,
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 2", Timestamp: "1234567892.123456", ThreadTimestamp: "1234567890.123456"}},
+			{Msg: slack.Msg{User: "U3456789", Text: "Reply 3", Timestamp: "1234567893.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, false, "", nil).Once()
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id": "C1234567",
+					"thread_ts":  "1234567890.123456",
+					"fetch_all":  true,
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		messages := response["messages"].([]interface{})
+		assert.Len(t, messages, 4)
+		for i, text := range []string{"Parent", "Reply 1", "Reply 2", "Reply 3"} {
+			assert.Equal(t, text, messages[i].(map[string]interface{})["text"])
+		}
+		assert.Equal(t, false, response["has_more"])
+		assert.NotContains(t, response, "next_cursor")
+		assert.NotContains(t, response, "truncated")
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fetch_all stops at max_messages and reports truncation", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     2,
+		}).Return([]slack.Message{
+			{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 5}},
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 1", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, true, "cursor-2", nil).Once()
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id":   "C1234567",
+					"thread_ts":    "1234567890.123456",
+					"fetch_all":    true,
+					"max_messages": 2,
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response["messages"], 2)
+		assert.Equal(t, true, response["truncated"])
+		assert.Equal(t, true, response["has_more"])
+		assert.Equal(t, "cursor-2", response["next_cursor"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fetch_all stops before a page that would overflow max_messages", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		parent := slack.Message{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 5}}
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     3,
+		}).Return([]slack.Message{
+			parent,
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 1", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, true, "cursor-2", nil).Once()
+		// The repeated parent is not counted, but the page still holds two new replies for one remaining slot
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Cursor:    "cursor-2",
+			Limit:     1,
+		}).Return([]slack.Message{
+			parent,
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 2", Timestamp: "1234567892.123456", ThreadTimestamp: "1234567890.123456"}},
+			{Msg: slack.Msg{User: "U3456789", Text: "Reply 3", Timestamp: "1234567893.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, true, "cursor-3", nil).Once()
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		workspaceRepo := NewWorkspaceRepository()
+		t.Cleanup(workspaceRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository:   channelRepo,
+			workspaceRepository: workspaceRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id":   "C1234567",
+					"thread_ts":    "1234567890.123456",
+					"fetch_all":    true,
+					"max_messages": 3,
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		messages := response["messages"].([]interface{})
+		assert.Len(t, messages, 2)
+		for i, text := range []string{"Parent", "Reply 1"} {
+			assert.Equal(t, text, messages[i].(map[string]interface{})["text"])
+		}
+		assert.Equal(t, true, response["truncated"])
+		assert.Equal(t, true, response["has_more"])
+		// Resuming from the dropped page's cursor returns Reply 2 and Reply 3 without a gap
+		assert.Equal(t, "cursor-2", response["next_cursor"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fetch_all stops when the context is canceled", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id": "C1234567",
+					"thread_ts":  "1234567890.123456",
+					"fetch_all":  true,
+				},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		res, err := handler.GetThreadReplies(ctx, req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "fetch_all was interrupted after 0 messages: context canceled", res.Content[0].(mcp.TextContent).Text)
+
+		mockClient.AssertNotCalled(t, "GetConversationReplies", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fetch_all rejects out of range max_messages", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_thread_replies",
+				Arguments: map[string]interface{}{
+					"channel_id":   "C1234567",
+					"thread_ts":    "1234567890.123456",
+					"fetch_all":    true,
+					"max_messages": 10001,
+				},
+			},
+		}
+
+		res, err := handler.GetThreadReplies(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "max_messages must be between 1 and 10000, got 10001", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("supports each conversation type", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			channelID string
+		}{
+			{"public or private channel", "C1234567"},
+			{"legacy private channel or group direct message", "G1234567"},
+			{"direct message", "D1234567"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+					ChannelID: tc.channelID,
+					Timestamp: "1234567890.123456",
+					Limit:     100,
+				}).Return([]slack.Message{
+					{Msg: slack.Msg{User: "U1234567", Text: "Hello", Timestamp: "1234567890.123456"}},
+				}, false, "", nil)
+				mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+
+				mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+				channelRepo := NewChannelRepository()
+				t.Cleanup(channelRepo.Close)
+				workspaceRepo := NewWorkspaceRepository()
+				t.Cleanup(workspaceRepo.Close)
+				handler := &Handler{
+					getClient: func(ctx context.Context) (SlackClient, error) {
+						return mockClient, nil
+					},
+					channelRepository:   channelRepo,
+					workspaceRepository: workspaceRepo,
+				}
+
+				req := mcp.CallToolRequest{
+					Params: struct {
+						Name      string    `json:"name"`
+						Arguments any       `json:"arguments,omitempty"`
+						Meta      *mcp.Meta `json:"_meta,omitempty"`
+					}{
+						Name: "get_thread_replies",
+						Arguments: map[string]interface{}{
+							"channel_id": tc.channelID,
+							"thread_ts":  "1234567890.123456",
+						},
+					},
+				}
+
+				res, err := handler.GetThreadReplies(t.Context(), req)
+				assert.NoError(t, err)
+				assert.False(t, res.IsError)
+
+				var response map[string]interface{}
+				err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.channelID, response["channel"].(map[string]interface{})["id"])
+				assert.Len(t, response["messages"], 1)
+
+				mockClient.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("returns scope-specific error when history scope is missing", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			channelID string
+			expectErr string
+		}{
+			{
+				"public or private channel",
+				"C1234567",
+				"missing required scope: reading conversation C1234567 requires the channels:history or groups:history scope. Please add it to your Slack app's User Token Scopes and reinstall the app",
+			},
+			{
+				"legacy private channel or group direct message",
+				"G1234567",
+				"missing required scope: reading conversation G1234567 requires the groups:history or mpim:history scope. Please add it to your Slack app's User Token Scopes and reinstall the app",
+			},
+			{
+				"direct message",
+				"D1234567",
+				"missing required scope: reading conversation D1234567 requires the im:history scope. Please add it to your Slack app's User Token Scopes and reinstall the app",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetConversationReplies", mock.Anything, mock.Anything).Return(nil, false, "", fmt.Errorf("%w: missing_scope", ErrMissingScope))
+				mockClient.On("GetConversationInfo", mock.Anything, mock.Anything).Return(nil, ErrChannelNotFound)
+
+				mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil)
+
+				channelRepo := NewChannelRepository()
+				t.Cleanup(channelRepo.Close)
+				workspaceRepo := NewWorkspaceRepository()
+				t.Cleanup(workspaceRepo.Close)
+				handler := &Handler{
+					getClient: func(ctx context.Context) (SlackClient, error) {
+						return mockClient, nil
+					},
+					channelRepository:   channelRepo,
+					workspaceRepository: workspaceRepo,
+				}
+
+				req := mcp.CallToolRequest{
+					Params: struct {
+						Name      string    `json:"name"`
+						Arguments any       `json:"arguments,omitempty"`
+						Meta      *mcp.Meta `json:"_meta,omitempty"`
+					}{
+						Name: "get_thread_replies",
+						Arguments: map[string]interface{}{
+							"channel_id": tc.channelID,
+							"thread_ts":  "1234567890.123456",
+						},
+					},
+				}
+
+				res, err := handler.GetThreadReplies(t.Context(), req)
+				assert.NoError(t, err)
+				assert.True(t, res.IsError)
+				assert.Equal(t, tc.expectErr, res.Content[0].(mcp.TextContent).Text)
+			})
+		}
+	})
 }
 
 func TestHandler_buildThreadRepliesParams(t *testing.T) {
@@ -226,6 +778,19 @@ func TestHandler_buildThreadRepliesParams(t *testing.T) {
 		}, params)
 	})
 
+	t.Run("accepts private channel and direct message IDs", func(t *testing.T) {
+		for _, channelID := range []string{"G1234567", "D1234567"} {
+			params, err := handler.buildThreadRepliesParams(buildThreadRepliesRequest{
+				ChannelID: channelID,
+				ThreadTS:  "1234567890.123456",
+				Limit:     100,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, channelID, params.ChannelID)
+		}
+	})
+
 	t.Run("channel_id validation errors", func(t *testing.T) {
 		testCases := []struct {
 			name      string
@@ -240,7 +805,7 @@ func TestHandler_buildThreadRepliesParams(t *testing.T) {
 			{
 				"invalid channel_id format",
 				buildThreadRepliesRequest{ChannelID: "invalid123", ThreadTS: "1234567890.123456", Limit: 100},
-				"invalid channel ID format. Must start with 'C' (e.g., 'C1234567')",
+				"invalid channel ID format. Must start with 'C', 'G' or 'D' (e.g., 'C1234567')",
 			},
 		}
 
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetUserGroupMembersResponse represents the output for get_user_group_members tool
+type GetUserGroupMembersResponse struct {
+	ID        string   `json:"id"`
+	Handle    string   `json:"handle"`
+	Name      string   `json:"name"`
+	MemberIDs []string `json:"member_ids"`
+	// Members is set only when include_user_info is enabled
+	Members []UserGroupMember `json:"members,omitempty"`
+}
+
+// UserGroupMember holds the names of a user group member
+type UserGroupMember struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name,omitempty"`
+	RealName string `json:"real_name,omitempty"`
+}
+
+// GetUserGroupMembers handles the get_user_group_members tool call
+func (h *Handler) GetUserGroupMembers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	ref := request.GetString("user_group", "")
+	if ref == "" {
+		return mcp.NewToolResultError("user_group is required"), nil
+	}
+
+	group, err := h.userGroupRepository.FindByRef(ctx, client, ref)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if group == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("user group not found: '%s'. Use list_user_groups to find the correct handle or ID", ref)), nil
+	}
+
+	memberIDs, err := h.userGroupRepository.GetMembers(ctx, client, group.ID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response := GetUserGroupMembersResponse{
+		ID:        group.ID,
+		Handle:    group.Handle,
+		Name:      group.Name,
+		MemberIDs: memberIDs,
+	}
+	if response.MemberIDs == nil {
+		response.MemberIDs = []string{}
+	}
+
+	if request.GetBool("include_user_info", false) {
+		userInfo := h.lookupMessageUserInfo(ctx, client, memberIDs)
+		response.Members = make([]UserGroupMember, 0, len(memberIDs))
+		for _, id := range memberIDs {
+			response.Members = append(response.Members, UserGroupMember{
+				UserID:   id,
+				UserName: userInfo[id].UserName,
+				RealName: userInfo[id].RealName,
+			})
+		}
+	}
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetUserGroupMembers(t *testing.T) {
+	newRequest := func(arguments map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name:      "get_user_group_members",
+				Arguments: arguments,
+			},
+		}
+	}
+	newHandler := func(t *testing.T, mockClient *SlackClientMock) *Handler {
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		userGroupRepo := NewUserGroupRepository()
+		t.Cleanup(userGroupRepo.Close)
+		return &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository:      userRepo,
+			userGroupRepository: userGroupRepo,
+		}
+	}
+
+	t.Run("gets members by handle", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", mock.Anything).Return(newTestUserGroups(), nil)
+		mockClient.On("GetUserGroupMembers", mock.Anything, "S2222222").Return([]string{"U1111111", "U2222222"}, nil)
+
+		res, err := newHandler(t, mockClient).GetUserGroupMembers(t.Context(), newRequest(map[string]interface{}{
+			"user_group": "@sre",
+		}))
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+		assert.JSONEq(t, `{"id":"S2222222","handle":"sre","name":"Site Reliability","member_ids":["U1111111","U2222222"]}`, res.Content[0].(mcp.TextContent).Text)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("includes member names with include_user_info", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", mock.Anything).Return(newTestUserGroups(), nil)
+		mockClient.On("GetUserGroupMembers", mock.Anything, "S2222222").Return([]string{"U1111111", "U2222222"}, nil)
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1111111", Name: "jdoe", Profile: slack.UserProfile{DisplayName: "john", RealName: "John Doe"}},
+		}, nil)
+
+		res, err := newHandler(t, mockClient).GetUserGroupMembers(t.Context(), newRequest(map[string]interface{}{
+			"user_group":        "S2222222",
+			"include_user_info": true,
+		}))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"user_id": "U1111111", "user_name": "john", "real_name": "John Doe"},
+			map[string]interface{}{"user_id": "U2222222"},
+		}, response["members"])
+	})
+
+	t.Run("returns error when user group is not found", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", mock.Anything).Return(newTestUserGroups(), nil)
+
+		res, err := newHandler(t, mockClient).GetUserGroupMembers(t.Context(), newRequest(map[string]interface{}{
+			"user_group": "@nobody",
+		}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "user group not found: '@nobody'. Use list_user_groups to find the correct handle or ID", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error when user_group is missing", func(t *testing.T) {
+		res, err := newHandler(t, &SlackClientMock{}).GetUserGroupMembers(t.Context(), newRequest(map[string]interface{}{}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "user_group is required", res.Content[0].(mcp.TextContent).Text)
+	})
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
 )
 
 func (h *Handler) GetUserProfiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -24,13 +28,22 @@ func (h *Handler) GetUserProfiles(ctx context.Context, request mcp.CallToolReque
 		return mcp.NewToolResultError("user_ids cannot exceed 100 entries"), nil
 	}
 
-	var profiles []UserProfile
-
-	for _, userID := range userIDs {
-		profile := h.getUserProfile(client, userID)
-		profiles = append(profiles, profile)
+	selected := make(userProfileFieldSet)
+	for _, field := range request.GetStringSlice("fields", []string{}) {
+		switch f := UserProfileField(field); {
+		case f == UserProfileFieldAll:
+			for _, f := range userProfileFields {
+				selected[f] = true
+			}
+		case slices.Contains(userProfileFields, f):
+			selected[f] = true
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("fields must be any of title, timezone, status, is_bot, is_admin, deleted, is_restricted, custom_fields or all, got '%s'", field)), nil
+		}
 	}
 
+	profiles := h.getUserProfiles(ctx, client, userIDs, selected)
+
 	jsonData, err := json.Marshal(profiles)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
@@ -39,7 +52,84 @@ func (h *Handler) GetUserProfiles(ctx context.Context, request mcp.CallToolReque
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
-func (h *Handler) getUserProfile(client SlackClient, userID string) UserProfile {
+// UserProfileField selects an optional field returned by get_user_profiles
+type UserProfileField string
+
+const (
+	UserProfileFieldTitle UserProfileField = "title"
+	// UserProfileFieldTimezone returns tz and tz_offset
+	UserProfileFieldTimezone UserProfileField = "timezone"
+	// UserProfileFieldStatus returns status_text, status_emoji and status_expiration
+	UserProfileFieldStatus       UserProfileField = "status"
+	UserProfileFieldIsBot        UserProfileField = "is_bot"
+	UserProfileFieldIsAdmin      UserProfileField = "is_admin"
+	UserProfileFieldDeleted      UserProfileField = "deleted"
+	UserProfileFieldIsRestricted UserProfileField = "is_restricted"
+	UserProfileFieldCustomFields UserProfileField = "custom_fields"
+	// UserProfileFieldAll selects every field above
+	UserProfileFieldAll UserProfileField = "all"
+)
+
+var userProfileFields = []UserProfileField{
+	UserProfileFieldTitle,
+	UserProfileFieldTimezone,
+	UserProfileFieldStatus,
+	UserProfileFieldIsBot,
+	UserProfileFieldIsAdmin,
+	UserProfileFieldDeleted,
+	UserProfileFieldIsRestricted,
+	UserProfileFieldCustomFields,
+}
+
+type userProfileFieldSet map[UserProfileField]bool
+
+// needsUserInfo reports whether a selected field is only returned by users.info, not by users.profile.get
+func (s userProfileFieldSet) needsUserInfo() bool {
+	return s[UserProfileFieldTimezone] || s[UserProfileFieldIsBot] || s[UserProfileFieldIsAdmin] ||
+		s[UserProfileFieldDeleted] || s[UserProfileFieldIsRestricted]
+}
+
+// getUserProfiles returns one profile per unique user ID in the order the IDs first appear.
+// Users found in the cached users.list are answered without an API call unless custom fields are selected,
+// and the rest are fetched in parallel.
+// The cache can be up to cacheTTL old, so it is skipped when the status is requested since it changes often.
+func (h *Handler) getUserProfiles(ctx context.Context, client SlackClient, userIDs []string, selected userProfileFieldSet) []UserProfile {
+	uniqueIDs := make([]string, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+
+	var cached map[string]slack.User
+	if h.userRepository != nil && !selected[UserProfileFieldStatus] {
+		cached = h.userRepository.FindCachedByIDs(ctx, uniqueIDs)
+	}
+
+	var definitions map[string]slack.TeamProfileField
+	if selected[UserProfileFieldCustomFields] {
+		definitions = getCustomFieldDefinitions(ctx, client)
+	}
+
+	profiles := make([]UserProfile, len(uniqueIDs))
+	forEachConcurrently(len(uniqueIDs), h.maxConcurrency, func(i int) {
+		profiles[i] = h.getUserProfile(ctx, client, uniqueIDs[i], cached, selected, definitions)
+	})
+	return profiles
+}
+
+// getUserProfile takes a user from cached or fetches it with users.profile.get, or with users.info when a selected field needs it.
+// Only users.profile.get returns custom fields, so it is also called for them when the user came from elsewhere.
+func (h *Handler) getUserProfile(
+	ctx context.Context,
+	client SlackClient,
+	userID string,
+	cached map[string]slack.User,
+	selected userProfileFieldSet,
+	definitions map[string]slack.TeamProfileField,
+) UserProfile {
 	if !strings.HasPrefix(userID, "U") {
 		return UserProfile{
 			UserID: userID,
@@ -47,18 +137,120 @@ func (h *Handler) getUserProfile(client SlackClient, userID string) UserProfile
 		}
 	}
 
-	slackProfile, err := client.GetUserProfile(userID)
-	if err != nil {
-		return UserProfile{
-			UserID: userID,
-			Error:  err.Error(),
+	user, ok := cached[userID]
+	fromProfileGet := false
+	switch {
+	case ok:
+	case selected.needsUserInfo():
+		slackUser, err := client.GetUserInfo(ctx, userID)
+		if err != nil {
+			return UserProfile{
+				UserID: userID,
+				Error:  err.Error(),
+			}
+		}
+		user = *slackUser
+	default:
+		slackProfile, err := client.GetUserProfile(ctx, userID)
+		if err != nil {
+			return UserProfile{
+				UserID: userID,
+				Error:  err.Error(),
+			}
 		}
+		user = slack.User{ID: userID, Profile: *slackProfile}
+		fromProfileGet = true
 	}
 
-	return UserProfile{
-		UserID:      userID,
-		DisplayName: slackProfile.DisplayName,
-		RealName:    slackProfile.RealName,
-		Email:       slackProfile.Email,
+	if selected[UserProfileFieldCustomFields] && !fromProfileGet {
+		slackProfile, err := client.GetUserProfile(ctx, userID)
+		if err != nil {
+			profile := newUserProfile(user, selected, definitions)
+			profile.CustomFields = nil
+			profile.Error = fmt.Sprintf("failed to get custom fields: %v", err)
+			return profile
+		}
+		user.Profile.Fields = slackProfile.Fields
+	}
+	return newUserProfile(user, selected, definitions)
+}
+
+// newUserProfile converts a user to a UserProfile with the selected fields
+func newUserProfile(user slack.User, selected userProfileFieldSet, definitions map[string]slack.TeamProfileField) UserProfile {
+	profile := UserProfile{
+		UserID:      user.ID,
+		DisplayName: user.Profile.DisplayName,
+		RealName:    user.Profile.RealName,
+		Email:       user.Profile.Email,
+	}
+	if selected[UserProfileFieldTitle] {
+		profile.Title = user.Profile.Title
+	}
+	if selected[UserProfileFieldTimezone] {
+		profile.TZ = user.TZ
+		profile.TZOffset = &user.TZOffset
+	}
+	if selected[UserProfileFieldStatus] {
+		profile.StatusText = user.Profile.StatusText
+		profile.StatusEmoji = user.Profile.StatusEmoji
+		profile.StatusExpiration = user.Profile.StatusExpiration
+	}
+	if selected[UserProfileFieldIsBot] {
+		profile.IsBot = &user.IsBot
+	}
+	if selected[UserProfileFieldIsAdmin] {
+		profile.IsAdmin = &user.IsAdmin
+	}
+	if selected[UserProfileFieldDeleted] {
+		profile.Deleted = &user.Deleted
+	}
+	if selected[UserProfileFieldIsRestricted] {
+		profile.IsRestricted = &user.IsRestricted
+	}
+
+	if selected[UserProfileFieldCustomFields] {
+		profile.CustomFields = convertCustomProfileFields(user.Profile.Fields, definitions)
+	}
+	return profile
+}
+
+// getCustomFieldDefinitions returns the workspace's custom profile fields keyed by ID.
+// Labels are a nicety, so a failure is logged and the field IDs are used as labels instead.
+func getCustomFieldDefinitions(ctx context.Context, client SlackClient) map[string]slack.TeamProfileField {
+	teamProfile, err := client.GetTeamProfile(ctx)
+	if err != nil {
+		slog.Warn("failed to get custom profile field labels", "error", err)
+		return nil
+	}
+
+	definitions := make(map[string]slack.TeamProfileField, len(teamProfile.Fields))
+	for _, field := range teamProfile.Fields {
+		definitions[field.ID] = field
+	}
+	return definitions
+}
+
+// convertCustomProfileFields returns the non-empty custom fields in the workspace's display order, skipping hidden fields
+func convertCustomProfileFields(fields slack.UserProfileCustomFields, definitions map[string]slack.TeamProfileField) []CustomProfileField {
+	var result []CustomProfileField
+	for id, field := range fields.ToMap() {
+		definition := definitions[id]
+		if field.Value == "" || definition.IsHidden {
+			continue
+		}
+		result = append(result, CustomProfileField{
+			ID:    id,
+			Label: cmp.Or(definition.Label, field.Label, id),
+			Value: field.Value,
+			Alt:   field.Alt,
+		})
 	}
+
+	slices.SortFunc(result, func(a, b CustomProfileField) int {
+		return cmp.Or(
+			cmp.Compare(definitions[a.ID].Ordering, definitions[b.ID].Ordering),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return result
 }
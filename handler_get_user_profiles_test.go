@@ -4,22 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_GetUserProfiles(t *testing.T) {
 	t.Run("can retrieve profiles for multiple users", func(t *testing.T) {
 		mockClient := &SlackClientMock{}
-		mockClient.On("GetUserProfile", "U1234567").Return(&slack.UserProfile{
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(&slack.UserProfile{
 			DisplayName: "john",
 			RealName:    "John Doe",
 			Email:       "john@example.com",
 		}, nil)
-		mockClient.On("GetUserProfile", "U2345678").Return(&slack.UserProfile{
+		mockClient.On("GetUserProfile", mock.Anything, "U2345678").Return(&slack.UserProfile{
 			DisplayName: "jane",
 			RealName:    "Jane Doe",
 			Email:       "jane@example.com",
@@ -62,14 +65,46 @@ func TestHandler_GetUserProfiles(t *testing.T) {
 		assert.Equal(t, "jane@example.com", profiles[1]["email"])
 	})
 
+	t.Run("passes the request context to the Slack client", func(t *testing.T) {
+		ctx := WithSessionID(t.Context(), "session-1")
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.MatchedBy(func(c context.Context) bool {
+			return SessionIDFromContext(c) == "session-1"
+		}), "U1234567").Return(&slack.UserProfile{DisplayName: "john"}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+				},
+			},
+		}
+		_, err := handler.GetUserProfiles(ctx, req)
+		assert.NoError(t, err)
+
+		mockClient.AssertExpectations(t)
+	})
+
 	t.Run("can retrieve other user profiles even if one user fails", func(t *testing.T) {
 		mockClient := &SlackClientMock{}
-		mockClient.On("GetUserProfile", "U1234567").Return(&slack.UserProfile{
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(&slack.UserProfile{
 			DisplayName: "john",
 			RealName:    "John Doe",
 			Email:       "john@example.com",
 		}, nil)
-		mockClient.On("GetUserProfile", "U2345678").Return(nil, errors.New("user not found"))
+		mockClient.On("GetUserProfile", mock.Anything, "U2345678").Return(nil, errors.New("user not found"))
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -105,4 +140,428 @@ func TestHandler_GetUserProfiles(t *testing.T) {
 		assert.Equal(t, "U2345678", profiles[1]["user_id"])
 		assert.Equal(t, "user not found", profiles[1]["error"])
 	})
+
+	t.Run("deduplicates IDs, keeps order and answers cached users without API calls", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane", RealName: "Jane Doe", Email: "jane@example.com"}},
+		}, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(&slack.UserProfile{DisplayName: "john"}, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U3456789").Return(&slack.UserProfile{DisplayName: "bob"}, nil).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		// Warm up the users.list cache as search_users_by_name would
+		_, err := userRepo.SearchUsers(t.Context(), mockClient, "jane", UserSearchFieldDisplayName, UserMatchExact)
+		assert.NoError(t, err)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+			maxConcurrency: 4,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567", "U2345678", "U1234567", "U3456789", "invalid"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		var profiles []map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &profiles)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(profiles))
+
+		assert.Equal(t, "U1234567", profiles[0]["user_id"])
+		assert.Equal(t, "john", profiles[0]["display_name"])
+		assert.Equal(t, "U2345678", profiles[1]["user_id"])
+		assert.Equal(t, "jane", profiles[1]["display_name"])
+		assert.Equal(t, "jane@example.com", profiles[1]["email"])
+		assert.Equal(t, "U3456789", profiles[2]["user_id"])
+		assert.Equal(t, "bob", profiles[2]["display_name"])
+		assert.Equal(t, "invalid", profiles[3]["user_id"])
+		assert.Equal(t, "invalid user ID format. Must start with 'U' (e.g., 'U1234567')", profiles[3]["error"])
+
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "GetUserProfile", mock.Anything, "U2345678")
+	})
+
+	t.Run("fetches status fresh even for cached users", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane", StatusText: "in a meeting"}},
+		}, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U2345678").Return(&slack.UserProfile{DisplayName: "jane", StatusText: "on vacation"}, nil).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		_, err := userRepo.SearchUsers(t.Context(), mockClient, "jane", UserSearchFieldDisplayName, UserMatchExact)
+		assert.NoError(t, err)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U2345678"},
+					"fields":   []string{"status"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		var profiles []map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &profiles)
+		assert.NoError(t, err)
+		assert.Equal(t, "on vacation", profiles[0]["status_text"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns only the basic fields unless selected", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(&slack.UserProfile{
+			DisplayName: "john",
+			Title:       "Engineer",
+			StatusText:  "On vacation",
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `[{"user_id":"U1234567","display_name":"john"}]`, res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns selected account fields from users.info", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserInfo", mock.Anything, "U1234567").Return(&slack.User{
+			ID:       "U1234567",
+			TZ:       "Asia/Tokyo",
+			TZOffset: 32400,
+			IsAdmin:  true,
+			Profile: slack.UserProfile{
+				DisplayName:      "john",
+				Title:            "Engineer",
+				StatusText:       "On vacation",
+				StatusEmoji:      ":palm_tree:",
+				StatusExpiration: 1700000000,
+			},
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+					"fields":   []string{"title", "timezone", "status", "is_bot", "is_admin", "deleted", "is_restricted"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `[{
+			"user_id": "U1234567",
+			"display_name": "john",
+			"title": "Engineer",
+			"tz": "Asia/Tokyo",
+			"tz_offset": 32400,
+			"status_text": "On vacation",
+			"status_emoji": ":palm_tree:",
+			"status_expiration": 1700000000,
+			"is_bot": false,
+			"is_admin": true,
+			"deleted": false,
+			"is_restricted": false
+		}]`, res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertNotCalled(t, "GetUserProfile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("resolves custom field labels with team.profile.get", func(t *testing.T) {
+		profile := &slack.UserProfile{DisplayName: "john"}
+		profile.SetFieldsMap(map[string]slack.UserProfileCustomField{
+			"Xf01": {Value: "Platform"},
+			"Xf02": {Value: "U2345678", Alt: "jane"},
+			"Xf03": {Value: "secret"},
+			"Xf04": {Value: ""},
+		})
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(profile, nil).Once()
+		mockClient.On("GetTeamProfile", mock.Anything).Return(&slack.TeamProfile{
+			Fields: []slack.TeamProfileField{
+				{ID: "Xf01", Label: "Team", Ordering: 1},
+				{ID: "Xf02", Label: "Manager", Ordering: 0},
+				{ID: "Xf03", Label: "Hidden", Ordering: 2, IsHidden: true},
+				{ID: "Xf04", Label: "Location", Ordering: 3},
+			},
+		}, nil).Once()
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+					"fields":   []string{"custom_fields"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `[{
+			"user_id": "U1234567",
+			"display_name": "john",
+			"custom_fields": [
+				{"id": "Xf02", "label": "Manager", "value": "U2345678", "alt": "jane"},
+				{"id": "Xf01", "label": "Team", "value": "Platform"}
+			]
+		}]`, res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fetches custom fields for cached users and uses IDs when labels are unavailable", func(t *testing.T) {
+		profile := &slack.UserProfile{}
+		profile.SetFieldsMap(map[string]slack.UserProfileCustomField{
+			"Xf01": {Value: "Platform"},
+		})
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1234567", Deleted: true, Profile: slack.UserProfile{DisplayName: "john"}},
+		}, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(profile, nil).Once()
+		mockClient.On("GetTeamProfile", mock.Anything).Return(nil, errors.New("missing_scope")).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		_, err := userRepo.FindByIDs(t.Context(), mockClient, []string{"U1234567"})
+		assert.NoError(t, err)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+					"fields":   []string{"deleted", "custom_fields"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `[{
+			"user_id": "U1234567",
+			"display_name": "john",
+			"deleted": true,
+			"custom_fields": [{"id": "Xf01", "label": "Xf01", "value": "Platform"}]
+		}]`, res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "GetUserInfo", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fetches custom fields with users.profile.get when users.info is needed", func(t *testing.T) {
+		profile := &slack.UserProfile{}
+		profile.SetFieldsMap(map[string]slack.UserProfileCustomField{
+			"Xf01": {Value: "Platform"},
+		})
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserInfo", mock.Anything, "U1234567").Return(&slack.User{
+			ID:      "U1234567",
+			TZ:      "Asia/Tokyo",
+			Profile: slack.UserProfile{DisplayName: "john"},
+		}, nil).Once()
+		mockClient.On("GetUserInfo", mock.Anything, "U2345678").Return(&slack.User{
+			ID:      "U2345678",
+			TZ:      "UTC",
+			Profile: slack.UserProfile{DisplayName: "jane"},
+		}, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(profile, nil).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U2345678").Return(nil, errors.New("ratelimited")).Once()
+		mockClient.On("GetTeamProfile", mock.Anything).Return(&slack.TeamProfile{
+			Fields: []slack.TeamProfileField{{ID: "Xf01", Label: "Team"}},
+		}, nil).Once()
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567", "U2345678"},
+					"fields":   []string{"timezone", "custom_fields"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `[
+			{
+				"user_id": "U1234567",
+				"display_name": "john",
+				"tz": "Asia/Tokyo",
+				"tz_offset": 0,
+				"custom_fields": [{"id": "Xf01", "label": "Team", "value": "Platform"}]
+			},
+			{
+				"user_id": "U2345678",
+				"display_name": "jane",
+				"tz": "UTC",
+				"tz_offset": 0,
+				"error": "failed to get custom fields: ratelimited"
+			}
+		]`, res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return &SlackClientMock{}, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "get_user_profiles",
+				Arguments: map[string]interface{}{
+					"user_ids": []string{"U1234567"},
+					"fields":   []string{"title", "phone"},
+				},
+			},
+		}
+		res, err := handler.GetUserProfiles(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "fields must be any of title, timezone, status, is_bot, is_admin, deleted, is_restricted, custom_fields or all, got 'phone'", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("fetches profiles in parallel up to maxConcurrency", func(t *testing.T) {
+		const concurrency = 3
+
+		var inFlight, maxInFlight atomic.Int32
+		allStarted := make(chan struct{})
+		var started atomic.Int32
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				prev := maxInFlight.Load()
+				if current <= prev || maxInFlight.CompareAndSwap(prev, current) {
+					break
+				}
+			}
+			// The first batch waits until all workers are running, which only succeeds when calls overlap
+			if started.Add(1) == concurrency {
+				close(allStarted)
+			}
+			select {
+			case <-allStarted:
+			case <-time.After(time.Second):
+			}
+		}).Return(&slack.UserProfile{}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			maxConcurrency: concurrency,
+		}
+
+		userIDs := []string{"U0000001", "U0000002", "U0000003", "U0000004", "U0000005", "U0000006"}
+		profiles := handler.getUserProfiles(t.Context(), mockClient, userIDs, nil)
+
+		assert.Len(t, profiles, len(userIDs))
+		for i, id := range userIDs {
+			assert.Equal(t, id, profiles[i].UserID)
+		}
+		assert.Equal(t, int32(concurrency), maxInFlight.Load())
+	})
 }
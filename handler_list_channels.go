@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// ListChannelsResponse represents the output for list_channels tool
+type ListChannelsResponse struct {
+	Channels []ChannelSummary `json:"channels"`
+}
+
+// ChannelSummary represents a single channel in list_channels results
+type ChannelSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Topic       string `json:"topic,omitempty"`
+	Purpose     string `json:"purpose,omitempty"`
+	MemberCount int    `json:"member_count"`
+	Created     int64  `json:"created"`
+	IsPrivate   bool   `json:"is_private,omitempty"`
+	IsArchived  bool   `json:"is_archived,omitempty"`
+	IsMember    bool   `json:"is_member,omitempty"`
+}
+
+// ListChannels handles the list_channels tool call
+func (h *Handler) ListChannels(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	channels, err := h.channelRepository.FindChannels(ctx, client, ChannelFilter{
+		Name:            request.GetString("name", ""),
+		IncludeArchived: request.GetBool("include_archived", false),
+		MemberOnly:      request.GetBool("member_only", false),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response := h.convertToListChannelsResponse(channels)
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (h *Handler) convertToListChannelsResponse(channels []slack.Channel) *ListChannelsResponse {
+	response := &ListChannelsResponse{
+		Channels: make([]ChannelSummary, 0, len(channels)),
+	}
+
+	for _, channel := range channels {
+		response.Channels = append(response.Channels, ChannelSummary{
+			ID:          channel.ID,
+			Name:        channel.Name,
+			Topic:       channel.Topic.Value,
+			Purpose:     channel.Purpose.Value,
+			MemberCount: channel.NumMembers,
+			Created:     int64(channel.Created),
+			IsPrivate:   channel.IsPrivate,
+			IsArchived:  channel.IsArchived,
+			IsMember:    channel.IsMember,
+		})
+	}
+
+	return response
+}
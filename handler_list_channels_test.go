@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListChannels(t *testing.T) {
+	t.Run("can list channels filtered by name", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		devChannel := newTestChannel("C2222222", "team-dev", false, true)
+		devChannel.Topic.Value = "Development discussion"
+		devChannel.Purpose.Value = "For developers"
+		devChannel.NumMembers = 42
+		devChannel.Created = slack.JSONTime(1700000000)
+		devChannel.IsPrivate = true
+
+		channels := []slack.Channel{
+			newTestChannel("C1111111", "general", false, true),
+			devChannel,
+		}
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return(channels, "", nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "list_channels",
+				Arguments: map[string]interface{}{
+					"name": "dev",
+				},
+			},
+		}
+
+		res, err := handler.ListChannels(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		result := response["channels"].([]interface{})
+		assert.Len(t, result, 1)
+
+		channel := result[0].(map[string]interface{})
+		assert.Equal(t, "C2222222", channel["id"])
+		assert.Equal(t, "team-dev", channel["name"])
+		assert.Equal(t, "Development discussion", channel["topic"])
+		assert.Equal(t, "For developers", channel["purpose"])
+		assert.Equal(t, float64(42), channel["member_count"])
+		assert.Equal(t, float64(1700000000), channel["created"])
+		assert.Equal(t, true, channel["is_private"])
+		assert.Equal(t, true, channel["is_member"])
+		assert.NotContains(t, channel, "is_archived")
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns empty array when no channels match", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return([]slack.Channel{
+			newTestChannel("C1111111", "general", false, true),
+		}, "", nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "list_channels",
+				Arguments: map[string]interface{}{
+					"name": "nothing",
+				},
+			},
+		}
+
+		res, err := handler.ListChannels(t.Context(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"channels":[]}`, res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error when API call fails", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return(nil, "", errors.New("missing required scope: missing_scope"))
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name:      "list_channels",
+				Arguments: map[string]interface{}{},
+			},
+		}
+
+		res, err := handler.ListChannels(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "missing required scope: missing_scope", res.Content[0].(mcp.TextContent).Text)
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// ListUserGroupsResponse represents the output for list_user_groups tool
+type ListUserGroupsResponse struct {
+	UserGroups []UserGroupSummary `json:"user_groups"`
+}
+
+// UserGroupSummary represents a single user group in list_user_groups results
+type UserGroupSummary struct {
+	ID          string `json:"id"`
+	Handle      string `json:"handle"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	UserCount   int    `json:"user_count"`
+	IsDisabled  bool   `json:"is_disabled,omitempty"`
+}
+
+// ListUserGroups handles the list_user_groups tool call
+func (h *Handler) ListUserGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	groups, err := h.userGroupRepository.FindUserGroups(ctx, client, UserGroupFilter{
+		Query:           request.GetString("query", ""),
+		IncludeDisabled: request.GetBool("include_disabled", false),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response := h.convertToListUserGroupsResponse(groups)
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (h *Handler) convertToListUserGroupsResponse(groups []slack.UserGroup) *ListUserGroupsResponse {
+	response := &ListUserGroupsResponse{
+		UserGroups: make([]UserGroupSummary, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		response.UserGroups = append(response.UserGroups, UserGroupSummary{
+			ID:          group.ID,
+			Handle:      group.Handle,
+			Name:        group.Name,
+			Description: group.Description,
+			UserCount:   group.UserCount,
+			IsDisabled:  isUserGroupDisabled(group),
+		})
+	}
+
+	return response
+}
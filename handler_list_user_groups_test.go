@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListUserGroups(t *testing.T) {
+	t.Run("can list user groups filtered by query", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		groups := newTestUserGroups()
+		groups[0].Description = "Owns the platform"
+		mockClient.On("GetUserGroups", mock.Anything).Return(groups, nil)
+
+		userGroupRepo := NewUserGroupRepository()
+		t.Cleanup(userGroupRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userGroupRepository: userGroupRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "list_user_groups",
+				Arguments: map[string]interface{}{
+					"query":            "platform",
+					"include_disabled": true,
+				},
+			},
+		}
+
+		res, err := handler.ListUserGroups(t.Context(), req)
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"id":          "S1111111",
+				"handle":      "platform-team",
+				"name":        "Platform Team",
+				"description": "Owns the platform",
+				"user_count":  float64(3),
+			},
+			map[string]interface{}{
+				"id":          "S3333333",
+				"handle":      "old-platform",
+				"name":        "Old Platform",
+				"user_count":  float64(0),
+				"is_disabled": true,
+			},
+		}, response["user_groups"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns empty array when nothing matches", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", mock.Anything).Return(newTestUserGroups(), nil)
+
+		userGroupRepo := NewUserGroupRepository()
+		t.Cleanup(userGroupRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userGroupRepository: userGroupRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "list_user_groups",
+				Arguments: map[string]interface{}{
+					"query": "nothing",
+				},
+			},
+		}
+
+		res, err := handler.ListUserGroups(t.Context(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"user_groups":[]}`, res.Content[0].(mcp.TextContent).Text)
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// LookupUsersByEmail handles the lookup_users_by_email tool call
+func (h *Handler) LookupUsersByEmail(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	emails := request.GetStringSlice("emails", []string{})
+
+	if len(emails) == 0 {
+		return mcp.NewToolResultError("emails is required and cannot be empty"), nil
+	}
+	if len(emails) > 100 {
+		return mcp.NewToolResultError("emails cannot exceed 100 entries"), nil
+	}
+
+	profiles := h.lookupUsersByEmail(ctx, client, emails)
+
+	jsonData, err := json.Marshal(profiles)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// lookupUsersByEmail returns one profile per unique email (case-insensitive) in the order the emails first appear.
+// Users in the cached users.list are answered without an API call and the rest are looked up in parallel with users.lookupByEmail.
+// When users.lookupByEmail fails for a reason other than an unknown email (e.g., a missing scope), users.list is searched instead.
+func (h *Handler) lookupUsersByEmail(ctx context.Context, client SlackClient, emails []string) []UserProfile {
+	uniqueEmails := make([]string, 0, len(emails))
+	seen := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if key := strings.ToLower(email); !seen[key] {
+			seen[key] = true
+			uniqueEmails = append(uniqueEmails, email)
+		}
+	}
+
+	var cached map[string]slack.User
+	if h.userRepository != nil {
+		cached = h.userRepository.FindCachedByEmails(ctx, uniqueEmails)
+	}
+
+	// Only the basic fields are returned, keeping the requested email when the profile hides it
+	toProfile := func(user slack.User, email string) UserProfile {
+		profile := newUserProfile(user, nil, nil)
+		profile.Email = cmp.Or(profile.Email, email)
+		return profile
+	}
+
+	profiles := make([]UserProfile, len(uniqueEmails))
+	lookupErrs := make([]error, len(uniqueEmails))
+	forEachConcurrently(len(uniqueEmails), h.maxConcurrency, func(i int) {
+		email := uniqueEmails[i]
+		if !strings.Contains(email, "@") {
+			profiles[i] = UserProfile{Email: email, Error: "invalid email format (e.g., 'user@example.com')"}
+			return
+		}
+		if user, ok := cached[strings.ToLower(email)]; ok {
+			profiles[i] = toProfile(user, email)
+			return
+		}
+
+		user, err := client.GetUserByEmail(ctx, email)
+		switch {
+		case err == nil:
+			profiles[i] = toProfile(*user, email)
+		case errors.Is(err, ErrUserNotFound):
+			profiles[i] = UserProfile{Email: email, Error: "no user found with this email"}
+		default:
+			lookupErrs[i] = err
+		}
+	})
+
+	var fallbackEmails []string
+	for i, err := range lookupErrs {
+		if err != nil {
+			fallbackEmails = append(fallbackEmails, uniqueEmails[i])
+		}
+	}
+	if len(fallbackEmails) == 0 {
+		return profiles
+	}
+
+	users := map[string]slack.User{}
+	listErr := errors.New("users.list is not available")
+	if h.userRepository != nil {
+		slog.Debug("falling back to users.list for email lookup", "count", len(fallbackEmails))
+		users, listErr = h.userRepository.FindByEmails(ctx, client, fallbackEmails)
+	}
+	for i, err := range lookupErrs {
+		if err == nil {
+			continue
+		}
+		email := uniqueEmails[i]
+		switch user, ok := users[strings.ToLower(email)]; {
+		case listErr != nil:
+			profiles[i] = UserProfile{Email: email, Error: err.Error()}
+		case ok:
+			profiles[i] = toProfile(user, email)
+		default:
+			profiles[i] = UserProfile{Email: email, Error: "no user found with this email"}
+		}
+	}
+	return profiles
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_LookupUsersByEmail(t *testing.T) {
+	newRequest := func(emails []string) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "lookup_users_by_email",
+				Arguments: map[string]interface{}{
+					"emails": emails,
+				},
+			},
+		}
+	}
+	newHandler := func(t *testing.T, mockClient *SlackClientMock) *Handler {
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		return &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+	}
+	parse := func(t *testing.T, res *mcp.CallToolResult) []map[string]interface{} {
+		var profiles []map[string]interface{}
+		err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &profiles)
+		assert.NoError(t, err)
+		return profiles
+	}
+
+	t.Run("looks up users with per-entry errors", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserByEmail", mock.Anything, "john@example.com").Return(&slack.User{
+			ID:      "U1234567",
+			Profile: slack.UserProfile{DisplayName: "john", RealName: "John Doe", Email: "john@example.com"},
+		}, nil).Once()
+		mockClient.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, fmt.Errorf("%w: users_not_found", ErrUserNotFound))
+
+		res, err := newHandler(t, mockClient).LookupUsersByEmail(t.Context(), newRequest([]string{
+			"john@example.com", "nobody@example.com", "not-an-email", "JOHN@example.com",
+		}))
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		assert.Equal(t, []map[string]interface{}{
+			{"user_id": "U1234567", "display_name": "john", "real_name": "John Doe", "email": "john@example.com"},
+			{"user_id": "", "email": "nobody@example.com", "error": "no user found with this email"},
+			{"user_id": "", "email": "not-an-email", "error": "invalid email format (e.g., 'user@example.com')"},
+		}, parse(t, res))
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("falls back to users.list when users.lookupByEmail is not allowed", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: missing_scope", ErrMissingScope))
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane", Email: "Jane@example.com"}},
+		}, nil).Once()
+
+		res, err := newHandler(t, mockClient).LookupUsersByEmail(t.Context(), newRequest([]string{"jane@example.com", "nobody@example.com"}))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []map[string]interface{}{
+			{"user_id": "U2345678", "display_name": "jane", "email": "Jane@example.com"},
+			{"user_id": "", "email": "nobody@example.com", "error": "no user found with this email"},
+		}, parse(t, res))
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns the lookup error when users.list also fails", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(nil, fmt.Errorf("%w: missing_scope", ErrMissingScope))
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: missing_scope", ErrMissingScope))
+
+		res, err := newHandler(t, mockClient).LookupUsersByEmail(t.Context(), newRequest([]string{"jane@example.com"}))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []map[string]interface{}{
+			{"user_id": "", "email": "jane@example.com", "error": "missing required scope: missing_scope"},
+		}, parse(t, res))
+	})
+
+	t.Run("answers from the cached users.list without calling Slack", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane", Email: "jane@example.com"}},
+		}, nil).Once()
+
+		handler := newHandler(t, mockClient)
+		_, err := handler.userRepository.FindByIDs(t.Context(), mockClient, []string{"U2345678"})
+		assert.NoError(t, err)
+
+		res, err := handler.LookupUsersByEmail(t.Context(), newRequest([]string{"jane@example.com"}))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []map[string]interface{}{
+			{"user_id": "U2345678", "display_name": "jane", "email": "jane@example.com"},
+		}, parse(t, res))
+		mockClient.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validates the number of emails", func(t *testing.T) {
+		handler := newHandler(t, &SlackClientMock{})
+
+		res, err := handler.LookupUsersByEmail(t.Context(), newRequest([]string{}))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "emails is required and cannot be empty", res.Content[0].(mcp.TextContent).Text)
+
+		emails := make([]string, 101)
+		for i := range emails {
+			emails[i] = fmt.Sprintf("user%d@example.com", i)
+		}
+		res, err = handler.LookupUsersByEmail(t.Context(), newRequest(emails))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "emails cannot exceed 100 entries", res.Content[0].(mcp.TextContent).Text)
+	})
+}
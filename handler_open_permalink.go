@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/permalink"
+	"github.com/slack-go/slack"
+)
+
+// OpenPermalinkResponse represents the output for open_permalink tool
+type OpenPermalinkResponse struct {
+	Channel *ChannelInfo   `json:"channel"`
+	Message *ThreadMessage `json:"message"`
+	// ThreadTs is set when the message belongs to a thread
+	ThreadTs string `json:"thread_ts,omitempty"`
+	// Thread holds the parent message and replies when the message belongs to a thread
+	Thread        []ThreadMessage `json:"thread,omitempty"`
+	ThreadHasMore bool            `json:"thread_has_more,omitempty"`
+	NextCursor    string          `json:"next_cursor,omitempty"`
+}
+
+// OpenPermalink handles the open_permalink tool call
+func (h *Handler) OpenPermalink(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
+	}
+
+	rawURL := request.GetString("url", "")
+	if rawURL == "" {
+		return mcp.NewToolResultError("url is required"), nil
+	}
+	link, err := permalink.Parse(rawURL)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	threadLimit := request.GetInt("thread_limit", 100)
+	if threadLimit < 1 || threadLimit > 1000 {
+		return mcp.NewToolResultError(fmt.Sprintf("thread_limit must be between 1 and 1000, got %d", threadLimit)), nil
+	}
+
+	channel, err := h.resolveChannelID(ctx, client, link.ChannelID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := h.openPermalink(ctx, client, link, threadLimit)
+	if err != nil {
+		return mcp.NewToolResultError(mapHistoryError(err, link.ChannelID).Error()), nil
+	}
+	response.Channel = channel
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// openPermalink fetches the linked message and, when it belongs to a thread, the surrounding thread
+func (h *Handler) openPermalink(ctx context.Context, client SlackClient, link *permalink.Permalink, threadLimit int) (*OpenPermalinkResponse, error) {
+	threadTs := link.ThreadTs
+	var target *slack.Message
+
+	if threadTs == "" {
+		// The link has no thread context, so read the message itself from the channel history
+		history, err := client.GetConversationHistory(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: link.ChannelID,
+			Oldest:    link.Timestamp,
+			Latest:    link.Timestamp,
+			Inclusive: true,
+			Limit:     1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		target = findMessageByTs(history.Messages, link.Timestamp)
+		if target == nil {
+			return nil, fmt.Errorf("message not found: %s", link.Timestamp)
+		}
+		if target.ReplyCount == 0 {
+			message := h.convertToThreadMessage(*target, link.WorkspaceURL, link.ChannelID)
+			return &OpenPermalinkResponse{Message: &message}, nil
+		}
+		threadTs = target.Timestamp
+	}
+
+	thread, hasMore, nextCursor, err := client.GetConversationReplies(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: link.ChannelID,
+		Timestamp: threadTs,
+		Limit:     threadLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if target == nil {
+		target = findMessageByTs(thread, link.Timestamp)
+	}
+	if target == nil && hasMore {
+		// The reply is beyond the first page, so fetch it directly
+		replies, _, _, err := client.GetConversationReplies(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: link.ChannelID,
+			Timestamp: threadTs,
+			Oldest:    link.Timestamp,
+			Latest:    link.Timestamp,
+			Inclusive: true,
+			Limit:     1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		target = findMessageByTs(replies, link.Timestamp)
+	}
+	if target == nil {
+		return nil, fmt.Errorf("message not found: %s", link.Timestamp)
+	}
+
+	message := h.convertToThreadMessage(*target, link.WorkspaceURL, link.ChannelID)
+	threadResponse := h.convertToThreadResponse(thread, hasMore, nextCursor, link.WorkspaceURL, link.ChannelID)
+
+	return &OpenPermalinkResponse{
+		Message:       &message,
+		ThreadTs:      threadTs,
+		Thread:        threadResponse.Messages,
+		ThreadHasMore: threadResponse.HasMore,
+		NextCursor:    threadResponse.NextCursor,
+	}, nil
+}
+
+func findMessageByTs(messages []slack.Message, ts string) *slack.Message {
+	for i := range messages {
+		if messages[i].Timestamp == ts {
+			return &messages[i]
+		}
+	}
+	return nil
+}
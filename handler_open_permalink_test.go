@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_OpenPermalink(t *testing.T) {
+	newHandler := func(t *testing.T, mockClient *SlackClientMock) *Handler {
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		return &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+	}
+
+	newRequest := func(url string) mcp.CallToolRequest {
+		return mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "open_permalink",
+				Arguments: map[string]interface{}{
+					"url": url,
+				},
+			},
+		}
+	}
+
+	t.Run("opens a message outside of threads", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationHistory", mock.Anything, &slack.GetConversationHistoryParameters{
+			ChannelID: "C1234567",
+			Oldest:    "1234567890.123456",
+			Latest:    "1234567890.123456",
+			Inclusive: true,
+			Limit:     1,
+		}).Return(&slack.GetConversationHistoryResponse{
+			Messages: []slack.Message{
+				{Msg: slack.Msg{User: "U1234567", Text: "Hello", Timestamp: "1234567890.123456"}},
+			},
+		}, nil)
+
+		handler := newHandler(t, mockClient)
+
+		res, err := handler.OpenPermalink(t.Context(), newRequest("https://workspace.slack.com/archives/C1234567/p1234567890123456"))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		channel := response["channel"].(map[string]interface{})
+		assert.Equal(t, "C1234567", channel["id"])
+		assert.Equal(t, "general", channel["name"])
+
+		message := response["message"].(map[string]interface{})
+		assert.Equal(t, "U1234567", message["user"])
+		assert.Equal(t, "Hello", message["text"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567890123456", message["permalink"])
+
+		assert.NotContains(t, response, "thread")
+		assert.NotContains(t, response, "thread_ts")
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("opens a thread parent with its replies", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationHistory", mock.Anything, mock.Anything).Return(&slack.GetConversationHistoryResponse{
+			Messages: []slack.Message{
+				{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 1}},
+			},
+		}, nil)
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     100,
+		}).Return([]slack.Message{
+			{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 1}},
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, false, "", nil)
+
+		handler := newHandler(t, mockClient)
+
+		res, err := handler.OpenPermalink(t.Context(), newRequest("https://workspace.slack.com/archives/C1234567/p1234567890123456"))
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Parent", response["message"].(map[string]interface{})["text"])
+		assert.Equal(t, "1234567890.123456", response["thread_ts"])
+		assert.Len(t, response["thread"], 2)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("opens a thread reply with thread context", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Limit:     2,
+		}).Return([]slack.Message{
+			{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 2}},
+			{Msg: slack.Msg{User: "U2345678", Text: "Reply 1", Timestamp: "1234567891.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, true, "next-cursor", nil)
+		mockClient.On("GetConversationReplies", mock.Anything, &slack.GetConversationRepliesParameters{
+			ChannelID: "C1234567",
+			Timestamp: "1234567890.123456",
+			Oldest:    "1234567892.123456",
+			Latest:    "1234567892.123456",
+			Inclusive: true,
+			Limit:     1,
+		}).Return([]slack.Message{
+			{Msg: slack.Msg{User: "U1234567", Text: "Parent", Timestamp: "1234567890.123456", ThreadTimestamp: "1234567890.123456", ReplyCount: 2}},
+			{Msg: slack.Msg{User: "U3456789", Text: "Reply 2", Timestamp: "1234567892.123456", ThreadTimestamp: "1234567890.123456"}},
+		}, false, "", nil)
+
+		handler := newHandler(t, mockClient)
+
+		req := newRequest("https://workspace.slack.com/archives/C1234567/p1234567892123456?thread_ts=1234567890.123456&cid=C1234567")
+		req.Params.Arguments.(map[string]interface{})["thread_limit"] = 2
+
+		res, err := handler.OpenPermalink(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		message := response["message"].(map[string]interface{})
+		assert.Equal(t, "Reply 2", message["text"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567892123456?thread_ts=1234567890.123456&cid=C1234567", message["permalink"])
+
+		assert.Equal(t, "1234567890.123456", response["thread_ts"])
+		assert.Len(t, response["thread"], 2)
+		assert.Equal(t, true, response["thread_has_more"])
+		assert.Equal(t, "next-cursor", response["next_cursor"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns error for invalid permalink", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		res, err := handler.OpenPermalink(t.Context(), newRequest("https://example.com/archives/C1234567/p1234567890123456"))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "invalid permalink: host must be a slack.com workspace domain", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("returns error when message does not exist", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetConversationHistory", mock.Anything, mock.Anything).Return(&slack.GetConversationHistoryResponse{}, nil)
+
+		handler := newHandler(t, mockClient)
+
+		res, err := handler.OpenPermalink(t.Context(), newRequest("https://workspace.slack.com/archives/C1234567/p1234567890123456"))
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "message not found: 1234567890.123456", res.Content[0].(mcp.TextContent).Text)
+	})
+}
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/permalink"
 	"github.com/slack-go/slack"
 )
 
@@ -18,29 +21,62 @@ type SearchFilesResponse struct {
 }
 
 type FileInfo struct {
-	ID        string   `json:"id"`
-	Title     string   `json:"title"`
-	Filetype  string   `json:"filetype"`
-	User      string   `json:"user"`
-	Channels  []string `json:"channels"`
-	Created   int64    `json:"created"`
-	Updated   int64    `json:"updated"`
-	Permalink string   `json:"permalink"`
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Filetype   string   `json:"filetype"`
+	Mimetype   string   `json:"mimetype"`
+	PrettyType string   `json:"pretty_type"`
+	Size       int      `json:"size"`
+	User       string   `json:"user"`
+	Channels   []string `json:"channels"`
+	Created    int64    `json:"created"`
+	Updated    int64    `json:"updated"`
+	Permalink  string   `json:"permalink"`
+	// Preview is the beginning of the file content, returned only when include_preview is true
+	Preview         string      `json:"preview,omitempty"`
+	CommentsCount   int         `json:"comments_count"`
+	IsExternal      bool        `json:"is_external"`
+	IsPublic        bool        `json:"is_public"`
+	PublicURLShared bool        `json:"public_url_shared"`
+	Shares          []FileShare `json:"shares,omitempty"`
+}
+
+// FileShare is a message that shared the file
+type FileShare struct {
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name,omitempty"`
+	Timestamp   string `json:"ts"`
+	ThreadTs    string `json:"thread_ts,omitempty"`
+	ReplyCount  int    `json:"reply_count,omitempty"`
+	// Private is set for shares in private channels and direct messages
+	Private   bool   `json:"private,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
 }
 
 type buildSearchFilesParamsRequest struct {
-	Query     string
-	Types     []string
-	InChannel string
-	FromUser  string
-	WithUsers []string
-	Before    string
-	After     string
-	On        string
-	Count     int
-	Page      int
+	Query           string
+	Types           []string
+	InChannel       string
+	ExcludeChannels []string
+	FromUser        string
+	ExcludeUsers    []string
+	WithUsers       []string
+	Is              []string
+	Before          string
+	After           string
+	On              string
+	Sort            string
+	SortDir         string
+	Count           int
+	Page            int
 }
 
+// maxSearchFilesChannels caps the number of in_channel and in_channels values, each of which costs one search
+const maxSearchFilesChannels = 10
+
+// fileIsFilters are the values accepted by the is parameter of search_files
+var fileIsFilters = []string{"saved", "starred"}
+
 // SearchFiles handles the search_files tool call
 func (h *Handler) SearchFiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := h.getClient(ctx)
@@ -48,28 +84,83 @@ func (h *Handler) SearchFiles(ctx context.Context, request mcp.CallToolRequest)
 		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
 	}
 
-	query, params, err := h.buildSearchFilesParams(buildSearchFilesParamsRequest{
-		Query:     request.GetString("query", ""),
-		Types:     request.GetStringSlice("types", []string{}),
-		InChannel: request.GetString("in_channel", ""),
-		FromUser:  request.GetString("from_user", ""),
-		WithUsers: request.GetStringSlice("with_users", []string{}),
-		Before:    request.GetString("before", ""),
-		After:     request.GetString("after", ""),
-		On:        request.GetString("on", ""),
-		Count:     request.GetInt("count", 20),
-		Page:      request.GetInt("page", 1),
-	})
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	// in_channel takes a single channel and in_channels several; files in any of them are returned
+	inChannels := request.GetStringSlice("in_channels", []string{})
+	if inChannel := request.GetString("in_channel", ""); inChannel != "" {
+		inChannels = append([]string{inChannel}, inChannels...)
+	}
+	inChannels = slices.DeleteFunc(inChannels, func(c string) bool { return c == "" })
+	if len(inChannels) > maxSearchFilesChannels {
+		return mcp.NewToolResultError(fmt.Sprintf("in_channel and in_channels accept at most %d channels in total, got %d", maxSearchFilesChannels, len(inChannels))), nil
+	}
+	// Each channel is searched separately, so there is no page of the merged results beyond the first
+	page := request.GetInt("page", 1)
+	if len(inChannels) > 1 && page != 1 {
+		return mcp.NewToolResultError(fmt.Sprintf("page must be 1 when searching more than one channel, got %d. Search the channels one at a time to read further pages", page)), nil
+	}
+	for i, channel := range inChannels {
+		inChannels[i], err = h.resolveChannelName(ctx, client, channel)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 	}
 
-	searchResult, err := client.SearchFiles(query, params)
-	if err != nil {
+	excludeChannels := slices.DeleteFunc(request.GetStringSlice("exclude_channels", []string{}), func(c string) bool { return c == "" })
+	for i, channel := range excludeChannels {
+		excludeChannels[i], err = h.resolveChannelName(ctx, client, channel)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	paramsRequest := buildSearchFilesParamsRequest{
+		Query:           request.GetString("query", ""),
+		Types:           request.GetStringSlice("types", []string{}),
+		ExcludeChannels: excludeChannels,
+		FromUser:        request.GetString("from_user", ""),
+		ExcludeUsers:    request.GetStringSlice("exclude_users", []string{}),
+		WithUsers:       request.GetStringSlice("with_users", []string{}),
+		Is:              request.GetStringSlice("is", []string{}),
+		Before:          request.GetString("before", ""),
+		After:           request.GetString("after", ""),
+		On:              request.GetString("on", ""),
+		Sort:            request.GetString("sort", "timestamp"),
+		SortDir:         request.GetString("sort_dir", "desc"),
+		Count:           request.GetInt("count", 20),
+		Page:            page,
+	}
+
+	// Slack combines modifiers with AND, so each channel is searched separately and the results are merged
+	if len(inChannels) == 0 {
+		inChannels = []string{""}
+	}
+	queries := make([]string, len(inChannels))
+	var params slack.SearchParameters
+	for i, channel := range inChannels {
+		paramsRequest.InChannel = channel
+		queries[i], params, err = h.buildSearchFilesParams(paramsRequest)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	results := make([]*slack.SearchFiles, len(queries))
+	errs := make([]error, len(queries))
+	forEachConcurrently(len(queries), h.maxConcurrency, func(i int) {
+		results[i], errs[i] = client.SearchFiles(ctx, queries[i], params)
+	})
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if len(queries) > 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to search files in channel '%s': %v", inChannels[i], err)), nil
+		}
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	response := h.convertToFilesResponse(searchResult)
+	searchResult := mergeSearchFiles(results, params.Sort, params.SortDirection, params.Count)
+	response := h.convertToFilesResponse(searchResult, request.GetBool("include_preview", false))
 
 	jsonData, err := json.Marshal(response)
 	if err != nil {
@@ -83,7 +174,7 @@ func (h *Handler) buildSearchFilesParams(request buildSearchFilesParamsRequest)
 	var queryParts []string
 
 	if request.Query != "" {
-		modifierPattern := regexp.MustCompile(`\b(from|in|before|after|on|type):`)
+		modifierPattern := regexp.MustCompile(`\b(from|in|before|after|on|during|has|is|with|type):`)
 		if modifierPattern.MatchString(request.Query) {
 			return "", slack.SearchParameters{}, fmt.Errorf("query field cannot contain modifiers (from:, in:, type:, etc.). Please use the dedicated fields")
 		}
@@ -100,6 +191,12 @@ func (h *Handler) buildSearchFilesParams(request buildSearchFilesParamsRequest)
 		queryParts = append(queryParts, fmt.Sprintf("in:%s", request.InChannel))
 	}
 
+	for _, channel := range request.ExcludeChannels {
+		if channel != "" {
+			queryParts = append(queryParts, fmt.Sprintf("-in:%s", channel))
+		}
+	}
+
 	if request.FromUser != "" {
 		if !strings.HasPrefix(request.FromUser, "U") {
 			return "", slack.SearchParameters{}, fmt.Errorf("invalid user ID format. Must start with 'U' (e.g., 'U1234567')")
@@ -116,6 +213,24 @@ func (h *Handler) buildSearchFilesParams(request buildSearchFilesParamsRequest)
 		}
 	}
 
+	for _, user := range request.ExcludeUsers {
+		if user != "" {
+			if !strings.HasPrefix(user, "U") {
+				return "", slack.SearchParameters{}, fmt.Errorf("invalid user ID format in exclude_users parameter: '%s'. Must start with 'U' (e.g., 'U1234567')", user)
+			}
+			queryParts = append(queryParts, fmt.Sprintf("-from:<@%s>", user))
+		}
+	}
+
+	for _, is := range request.Is {
+		if is != "" {
+			if !slices.Contains(fileIsFilters, is) {
+				return "", slack.SearchParameters{}, fmt.Errorf("is must be one of %s, got '%s'", strings.Join(fileIsFilters, ", "), is)
+			}
+			queryParts = append(queryParts, fmt.Sprintf("is:%s", is))
+		}
+	}
+
 	datePattern := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
 	if request.Before != "" {
 		if !datePattern.MatchString(request.Before) {
@@ -136,18 +251,15 @@ func (h *Handler) buildSearchFilesParams(request buildSearchFilesParamsRequest)
 		queryParts = append(queryParts, fmt.Sprintf("on:%s", request.On))
 	}
 
-	if request.Count < 1 || request.Count > 100 {
-		return "", slack.SearchParameters{}, fmt.Errorf("count must be between 1 and 100, got %d", request.Count)
-	}
-	if request.Page < 1 || request.Page > 100 {
-		return "", slack.SearchParameters{}, fmt.Errorf("page must be between 1 and 100, got %d", request.Page)
+	if err := validateSearchPaging(request.Count, request.Page, request.Sort, request.SortDir); err != nil {
+		return "", slack.SearchParameters{}, err
 	}
 
 	searchQuery := strings.Join(queryParts, " ")
 
 	params := slack.SearchParameters{
-		Sort:          "timestamp",
-		SortDirection: "desc",
+		Sort:          request.Sort,
+		SortDirection: request.SortDir,
 		Count:         request.Count,
 		Page:          request.Page,
 	}
@@ -155,21 +267,82 @@ func (h *Handler) buildSearchFilesParams(request buildSearchFilesParamsRequest)
 	return searchQuery, params, nil
 }
 
-func (h *Handler) convertToFilesResponse(result *slack.SearchFiles) *SearchFilesResponse {
+// mergeSearchFiles combines the results of per-channel searches, dropping files found in more than one channel.
+// Timestamp-sorted results are merged in order. Scores are not returned by Slack, so score-sorted results are interleaved by rank.
+// Only the first page of each search is merged and cut to count, so the pagination reports a single page with the summed totals.
+func mergeSearchFiles(results []*slack.SearchFiles, sortBy, sortDir string, count int) *slack.SearchFiles {
+	if len(results) == 1 {
+		return results[0]
+	}
+
+	merged := &slack.SearchFiles{}
+	seen := map[string]bool{}
+	add := func(file slack.File) {
+		if !seen[file.ID] {
+			seen[file.ID] = true
+			merged.Matches = append(merged.Matches, file)
+		}
+	}
+	for rank := 0; ; rank++ {
+		added := false
+		for _, result := range results {
+			if rank < len(result.Matches) {
+				add(result.Matches[rank])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	if sortBy == "timestamp" {
+		slices.SortStableFunc(merged.Matches, func(a, b slack.File) int {
+			if sortDir == "asc" {
+				return cmp.Compare(a.Timestamp, b.Timestamp)
+			}
+			return cmp.Compare(b.Timestamp, a.Timestamp)
+		})
+	}
+	if len(merged.Matches) > count {
+		merged.Matches = merged.Matches[:count]
+	}
+
+	for _, result := range results {
+		merged.Paging.Count = max(merged.Paging.Count, result.Paging.Count)
+		merged.Paging.Total += result.Paging.Total
+	}
+	merged.Paging.Page = 1
+	merged.Paging.Pages = 1
+	return merged
+}
+
+func (h *Handler) convertToFilesResponse(result *slack.SearchFiles, includePreview bool) *SearchFilesResponse {
 	response := &SearchFilesResponse{
 		Files: make([]FileInfo, 0, len(result.Matches)),
 	}
 
 	for _, match := range result.Matches {
 		file := FileInfo{
-			ID:        match.ID,
-			Title:     match.Title,
-			Filetype:  match.Filetype,
-			User:      match.User,
-			Channels:  match.Channels,
-			Created:   int64(match.Created),
-			Updated:   int64(match.Timestamp),
-			Permalink: match.Permalink,
+			ID:              match.ID,
+			Title:           match.Title,
+			Filetype:        match.Filetype,
+			Mimetype:        match.Mimetype,
+			PrettyType:      match.PrettyType,
+			Size:            match.Size,
+			User:            match.User,
+			Channels:        match.Channels,
+			Created:         int64(match.Created),
+			Updated:         int64(match.Timestamp),
+			Permalink:       match.Permalink,
+			CommentsCount:   match.CommentsCount,
+			IsExternal:      match.IsExternal,
+			IsPublic:        match.IsPublic,
+			PublicURLShared: match.PublicURLShared,
+			Shares:          convertToFileShares(match.Shares, permalink.ExtractWorkspaceURL(match.Permalink)),
+		}
+		if includePreview {
+			file.Preview = match.Preview
 		}
 		response.Files = append(response.Files, file)
 	}
@@ -185,3 +358,32 @@ func (h *Handler) convertToFilesResponse(result *slack.SearchFiles) *SearchFiles
 
 	return response
 }
+
+// convertToFileShares flattens the public and private shares of a file, oldest first.
+// The workspace URL comes from the file permalink so that no extra API call is needed to link to the messages.
+func convertToFileShares(shares slack.Share, workspaceURL string) []FileShare {
+	var result []FileShare
+	add := func(byChannel map[string][]slack.ShareFileInfo, private bool) {
+		for channelID, infos := range byChannel {
+			for _, info := range infos {
+				result = append(result, FileShare{
+					ChannelID:   channelID,
+					ChannelName: info.ChannelName,
+					Timestamp:   info.Ts,
+					ThreadTs:    info.ThreadTs,
+					ReplyCount:  info.ReplyCount,
+					Private:     private,
+					Permalink:   permalink.Build(workspaceURL, channelID, info.Ts, info.ThreadTs),
+				})
+			}
+		}
+	}
+	add(shares.Public, false)
+	add(shares.Private, true)
+
+	// Maps have no order, so sort to keep the output stable
+	slices.SortFunc(result, func(a, b FileShare) int {
+		return cmp.Or(strings.Compare(a.Timestamp, b.Timestamp), strings.Compare(a.ChannelID, b.ChannelID))
+	})
+	return result
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_buildSearchFilesParams(t *testing.T) {
@@ -15,9 +17,11 @@ func TestHandler_buildSearchFilesParams(t *testing.T) {
 
 	t.Run("query only with defaults", func(t *testing.T) {
 		request := buildSearchFilesParamsRequest{
-			Query: "hello world",
-			Count: 20,
-			Page:  1,
+			Query:   "hello world",
+			Sort:    "timestamp",
+			SortDir: "desc",
+			Count:   20,
+			Page:    1,
 		}
 
 		query, params, err := handler.buildSearchFilesParams(request)
@@ -34,25 +38,30 @@ func TestHandler_buildSearchFilesParams(t *testing.T) {
 
 	t.Run("all parameters specified", func(t *testing.T) {
 		request := buildSearchFilesParamsRequest{
-			Query:     "test file",
-			Types:     []string{"canvases", "pdfs"},
-			InChannel: "general",
-			FromUser:  "U1234567",
-			WithUsers: []string{"U2345678", "U3456789"},
-			Before:    "2024-01-15",
-			After:     "2024-01-01",
-			On:        "2024-01-10",
-			Count:     50,
-			Page:      2,
+			Query:           "test file",
+			Types:           []string{"canvases", "pdfs"},
+			InChannel:       "general",
+			ExcludeChannels: []string{"random", "bots"},
+			FromUser:        "U1234567",
+			ExcludeUsers:    []string{"U4567890"},
+			WithUsers:       []string{"U2345678", "U3456789"},
+			Is:              []string{"saved"},
+			Before:          "2024-01-15",
+			After:           "2024-01-01",
+			On:              "2024-01-10",
+			Sort:            "score",
+			SortDir:         "asc",
+			Count:           50,
+			Page:            2,
 		}
 
 		query, params, err := handler.buildSearchFilesParams(request)
 
 		assert.NoError(t, err)
-		assert.Equal(t, "test file type:canvases type:pdfs in:general from:<@U1234567> with:<@U2345678> with:<@U3456789> before:2024-01-15 after:2024-01-01 on:2024-01-10", query)
+		assert.Equal(t, "test file type:canvases type:pdfs in:general -in:random -in:bots from:<@U1234567> with:<@U2345678> with:<@U3456789> -from:<@U4567890> is:saved before:2024-01-15 after:2024-01-01 on:2024-01-10", query)
 		assert.Equal(t, slack.SearchParameters{
-			Sort:          "timestamp",
-			SortDirection: "desc",
+			Sort:          "score",
+			SortDirection: "asc",
 			Count:         50,
 			Page:          2,
 		}, params)
@@ -87,6 +96,11 @@ func TestHandler_buildSearchFilesParams(t *testing.T) {
 				buildSearchFilesParamsRequest{WithUsers: []string{"U1234567", "invaliduser"}, Count: 20, Page: 1},
 				"invalid user ID format in with_users parameter: 'invaliduser'. Must start with 'U' (e.g., 'U1234567')",
 			},
+			{
+				"invalid exclude_users",
+				buildSearchFilesParamsRequest{ExcludeUsers: []string{"invaliduser"}, Count: 20, Page: 1},
+				"invalid user ID format in exclude_users parameter: 'invaliduser'. Must start with 'U' (e.g., 'U1234567')",
+			},
 		}
 
 		for _, tc := range testCases {
@@ -99,6 +113,15 @@ func TestHandler_buildSearchFilesParams(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid is filter should error", func(t *testing.T) {
+		request := buildSearchFilesParamsRequest{Is: []string{"pinned"}, Sort: "timestamp", SortDir: "desc", Count: 20, Page: 1}
+
+		_, _, err := handler.buildSearchFilesParams(request)
+
+		assert.Error(t, err)
+		assert.Equal(t, "is must be one of saved, starred, got 'pinned'", err.Error())
+	})
+
 	t.Run("invalid date formats should error", func(t *testing.T) {
 		testCases := []struct {
 			name      string
@@ -158,6 +181,16 @@ func TestHandler_buildSearchFilesParams(t *testing.T) {
 				buildSearchFilesParamsRequest{Page: 101, Count: 20},
 				"page must be between 1 and 100, got 101",
 			},
+			{
+				"invalid sort",
+				buildSearchFilesParamsRequest{Sort: "relevance", SortDir: "desc", Count: 20, Page: 1},
+				"sort must be 'score' or 'timestamp', got 'relevance'",
+			},
+			{
+				"invalid sort_dir",
+				buildSearchFilesParamsRequest{Sort: "timestamp", SortDir: "up", Count: 20, Page: 1},
+				"sort_dir must be 'asc' or 'desc', got 'up'",
+			},
 		}
 
 		for _, tc := range testCases {
@@ -213,7 +246,7 @@ func TestHandler_SearchFiles(t *testing.T) {
 			Count:         20,
 			Page:          1,
 		}
-		mockClient.On("SearchFiles", expectedQuery, expectedParams).Return(mockResponse, nil)
+		mockClient.On("SearchFiles", mock.Anything, expectedQuery, expectedParams).Return(mockResponse, nil)
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -285,7 +318,7 @@ func TestHandler_SearchFiles(t *testing.T) {
 			},
 		}
 
-		mockClient.On("SearchFiles", expectedQuery, expectedParams).Return(mockResponse, nil)
+		mockClient.On("SearchFiles", mock.Anything, expectedQuery, expectedParams).Return(mockResponse, nil)
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -318,4 +351,295 @@ func TestHandler_SearchFiles(t *testing.T) {
 
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("returns file metadata, shares and preview when requested", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		mockResponse := &slack.SearchFiles{
+			Matches: []slack.File{
+				{
+					ID:              "F12345678",
+					Title:           "notes.txt",
+					Filetype:        "text",
+					Mimetype:        "text/plain",
+					PrettyType:      "Plain Text",
+					Size:            2048,
+					User:            "U1234567",
+					Permalink:       "https://workspace.slack.com/files/U1234567/F12345678/notes.txt",
+					Preview:         "first line of the notes",
+					CommentsCount:   3,
+					IsPublic:        true,
+					PublicURLShared: true,
+					Shares: slack.Share{
+						Public: map[string][]slack.ShareFileInfo{
+							"C2345678": {{Ts: "1704153600.000200", ChannelName: "random"}},
+							"C1234567": {{Ts: "1704067200.000100", ThreadTs: "1704067100.000000", ChannelName: "general", ReplyCount: 2}},
+						},
+						Private: map[string][]slack.ShareFileInfo{
+							"D1234567": {{Ts: "1704240000.000300"}},
+						},
+					},
+				},
+			},
+			Paging: slack.Paging{Count: 20, Total: 1, Page: 1, Pages: 1},
+		}
+		mockClient.On("SearchFiles", mock.Anything, "notes", mock.Anything).Return(mockResponse, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		search := func(arguments map[string]interface{}) map[string]interface{} {
+			req := mcp.CallToolRequest{
+				Params: struct {
+					Name      string    `json:"name"`
+					Arguments any       `json:"arguments,omitempty"`
+					Meta      *mcp.Meta `json:"_meta,omitempty"`
+				}{
+					Name:      "search_files",
+					Arguments: arguments,
+				},
+			}
+			res, err := handler.SearchFiles(t.Context(), req)
+			assert.NoError(t, err)
+			assert.False(t, res.IsError)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+			assert.NoError(t, err)
+			return response["files"].([]interface{})[0].(map[string]interface{})
+		}
+
+		file := search(map[string]interface{}{"query": "notes"})
+		assert.Equal(t, "text/plain", file["mimetype"])
+		assert.Equal(t, "Plain Text", file["pretty_type"])
+		assert.Equal(t, float64(2048), file["size"])
+		assert.Equal(t, float64(3), file["comments_count"])
+		assert.Equal(t, false, file["is_external"])
+		assert.Equal(t, true, file["is_public"])
+		assert.Equal(t, true, file["public_url_shared"])
+		assert.NotContains(t, file, "preview")
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"channel_id":   "C1234567",
+				"channel_name": "general",
+				"ts":           "1704067200.000100",
+				"thread_ts":    "1704067100.000000",
+				"reply_count":  float64(2),
+				"permalink":    "https://workspace.slack.com/archives/C1234567/p1704067200000100?thread_ts=1704067100.000000&cid=C1234567",
+			},
+			map[string]interface{}{
+				"channel_id":   "C2345678",
+				"channel_name": "random",
+				"ts":           "1704153600.000200",
+				"permalink":    "https://workspace.slack.com/archives/C2345678/p1704153600000200",
+			},
+			map[string]interface{}{
+				"channel_id": "D1234567",
+				"ts":         "1704240000.000300",
+				"private":    true,
+				"permalink":  "https://workspace.slack.com/archives/D1234567/p1704240000000300",
+			},
+		}, file["shares"])
+
+		file = search(map[string]interface{}{"query": "notes", "include_preview": true})
+		assert.Equal(t, "first line of the notes", file["preview"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("searches multiple channels in parallel and merges the results", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		expectedParams := slack.SearchParameters{
+			Sort:          "timestamp",
+			SortDirection: "desc",
+			Count:         20,
+			Page:          1,
+		}
+		mockClient.On("SearchFiles", mock.Anything, "report in:general -in:random", expectedParams).Return(&slack.SearchFiles{
+			Matches: []slack.File{
+				{ID: "F3", Timestamp: slack.JSONTime(1704240000)},
+				{ID: "F1", Timestamp: slack.JSONTime(1704067200)},
+			},
+			Paging: slack.Paging{Count: 20, Total: 2, Page: 1, Pages: 1},
+		}, nil)
+		mockClient.On("SearchFiles", mock.Anything, "report in:dev -in:random", expectedParams).Return(&slack.SearchFiles{
+			Matches: []slack.File{
+				{ID: "F2", Timestamp: slack.JSONTime(1704153600)},
+				{ID: "F1", Timestamp: slack.JSONTime(1704067200)},
+			},
+			Paging: slack.Paging{Count: 20, Total: 30, Page: 1, Pages: 2},
+		}, nil)
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			maxConcurrency: 2,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_files",
+				Arguments: map[string]interface{}{
+					"query":            "report",
+					"in_channels":      []interface{}{"general", "#dev"},
+					"exclude_channels": []interface{}{"random"},
+				},
+			},
+		}
+
+		res, err := handler.SearchFiles(t.Context(), req)
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		var ids []string
+		for _, file := range response["files"].([]interface{}) {
+			ids = append(ids, file.(map[string]interface{})["id"].(string))
+		}
+		assert.Equal(t, []string{"F3", "F2", "F1"}, ids)
+
+		pagination := response["pagination"].(map[string]interface{})
+		assert.Equal(t, float64(32), pagination["total_count"])
+		assert.Equal(t, float64(1), pagination["page"])
+		assert.Equal(t, float64(1), pagination["page_count"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("combines in_channel with in_channels and cuts the merged results to count", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		expectedParams := slack.SearchParameters{
+			Sort:          "timestamp",
+			SortDirection: "asc",
+			Count:         2,
+			Page:          1,
+		}
+		mockClient.On("SearchFiles", mock.Anything, "in:general", expectedParams).Return(&slack.SearchFiles{
+			Matches: []slack.File{
+				{ID: "F1", Timestamp: slack.JSONTime(1704067200)},
+				{ID: "F3", Timestamp: slack.JSONTime(1704240000)},
+			},
+			Paging: slack.Paging{Count: 2, Total: 2, Page: 1, Pages: 1},
+		}, nil).Once()
+		mockClient.On("SearchFiles", mock.Anything, "in:dev", expectedParams).Return(&slack.SearchFiles{
+			Matches: []slack.File{
+				{ID: "F2", Timestamp: slack.JSONTime(1704153600)},
+				{ID: "F4", Timestamp: slack.JSONTime(1704326400)},
+			},
+			Paging: slack.Paging{Count: 2, Total: 2, Page: 1, Pages: 1},
+		}, nil).Once()
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_files",
+				Arguments: map[string]interface{}{
+					"in_channel":  "general",
+					"in_channels": []interface{}{"dev"},
+					"sort_dir":    "asc",
+					"count":       2,
+				},
+			},
+		}
+
+		res, err := handler.SearchFiles(t.Context(), req)
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		var ids []string
+		for _, file := range response["files"].([]interface{}) {
+			ids = append(ids, file.(map[string]interface{})["id"].(string))
+		}
+		assert.Equal(t, []string{"F1", "F2"}, ids)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects pages beyond the first with multiple channels", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_files",
+				Arguments: map[string]interface{}{
+					"in_channel":  "general",
+					"in_channels": []interface{}{"dev"},
+					"page":        2,
+				},
+			},
+		}
+
+		res, err := handler.SearchFiles(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "page must be 1 when searching more than one channel, got 2. Search the channels one at a time to read further pages", res.Content[0].(mcp.TextContent).Text)
+		mockClient.AssertNotCalled(t, "SearchFiles", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns error when a channel search fails", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("SearchFiles", mock.Anything, "in:general", mock.Anything).Return(&slack.SearchFiles{}, nil)
+		mockClient.On("SearchFiles", mock.Anything, "in:dev", mock.Anything).Return(nil, errors.New("ratelimited"))
+
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_files",
+				Arguments: map[string]interface{}{
+					"in_channels": []interface{}{"general", "dev"},
+				},
+			},
+		}
+
+		res, err := handler.SearchFiles(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "failed to search files in channel 'dev': ratelimited", res.Content[0].(mcp.TextContent).Text)
+	})
 }
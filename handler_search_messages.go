@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shibayu36/slack-explorer-mcp/permalink"
 	"github.com/slack-go/slack"
 )
 
@@ -16,6 +17,8 @@ import (
 type SearchMessagesResponse struct {
 	WorkspaceURL string                 `json:"workspace_url"`
 	Messages     *SearchMessagesMatches `json:"messages"`
+	// Mentions maps each raw ID to its resolved mention. Set only when resolve_mentions is enabled.
+	Mentions map[string]string `json:"mentions,omitempty"`
 }
 
 type SearchMessagesMatches struct {
@@ -25,9 +28,12 @@ type SearchMessagesMatches struct {
 
 type SearchMessage struct {
 	User        string           `json:"user"`
+	UserName    string           `json:"user_name,omitempty"`
+	RealName    string           `json:"real_name,omitempty"`
 	Text        string           `json:"text"`
 	Attachments []AttachmentInfo `json:"attachments,omitempty"`
 	Timestamp   string           `json:"ts"`
+	Permalink   string           `json:"permalink,omitempty"`
 	Channel     *ChannelInfo     `json:"channel,omitempty"`
 	// Fill if the message is in a thread
 	ThreadTs string `json:"thread_ts,omitempty"`
@@ -40,9 +46,17 @@ func (h *Handler) SearchMessages(ctx context.Context, request mcp.CallToolReques
 		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
 	}
 
+	inChannel := request.GetString("in_channel", "")
+	if inChannel != "" {
+		inChannel, err = h.resolveChannelName(ctx, client, inChannel)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
 	query, params, err := h.buildSearchParams(buildSearchParamsRequest{
 		Query:     request.GetString("query", ""),
-		InChannel: request.GetString("in_channel", ""),
+		InChannel: inChannel,
 		FromUser:  request.GetString("from_user", ""),
 		With:      request.GetStringSlice("with", []string{}),
 		Before:    request.GetString("before", ""),
@@ -61,13 +75,34 @@ func (h *Handler) SearchMessages(ctx context.Context, request mcp.CallToolReques
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	searchResult, err := client.SearchMessages(query, params)
+	searchResult, err := client.SearchMessages(ctx, query, params)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	response := h.convertToSearchResponse(searchResult)
 
+	if request.GetBool("resolve_mentions", false) {
+		texts := make([]*string, 0, len(response.Messages.Matches))
+		for i := range response.Messages.Matches {
+			texts = append(texts, &response.Messages.Matches[i].Text)
+		}
+		response.Mentions = h.resolveMentions(ctx, client, texts)
+	}
+
+	if request.GetBool("include_user_info", false) {
+		userIDs := make([]string, 0, len(response.Messages.Matches))
+		for _, msg := range response.Messages.Matches {
+			userIDs = append(userIDs, msg.User)
+		}
+		userInfo := h.lookupMessageUserInfo(ctx, client, userIDs)
+		for i := range response.Messages.Matches {
+			info := userInfo[response.Messages.Matches[i].User]
+			response.Messages.Matches[i].UserName = info.UserName
+			response.Messages.Matches[i].RealName = info.RealName
+		}
+	}
+
 	jsonData, err := json.Marshal(response)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal response: %v", err)), nil
@@ -162,17 +197,8 @@ func (h *Handler) buildSearchParams(request buildSearchParamsRequest) (string, s
 		}
 	}
 
-	if request.Count < 1 || request.Count > 100 {
-		return "", slack.SearchParameters{}, fmt.Errorf("count must be between 1 and 100, got %d", request.Count)
-	}
-	if request.Page < 1 || request.Page > 100 {
-		return "", slack.SearchParameters{}, fmt.Errorf("page must be between 1 and 100, got %d", request.Page)
-	}
-	if request.Sort != "score" && request.Sort != "timestamp" {
-		return "", slack.SearchParameters{}, fmt.Errorf("sort must be 'score' or 'timestamp', got '%s'", request.Sort)
-	}
-	if request.SortDir != "asc" && request.SortDir != "desc" {
-		return "", slack.SearchParameters{}, fmt.Errorf("sort_dir must be 'asc' or 'desc', got '%s'", request.SortDir)
+	if err := validateSearchPaging(request.Count, request.Page, request.Sort, request.SortDir); err != nil {
+		return "", slack.SearchParameters{}, err
 	}
 
 	searchQuery := strings.Join(queryParts, " ")
@@ -188,33 +214,21 @@ func (h *Handler) buildSearchParams(request buildSearchParamsRequest) (string, s
 	return searchQuery, params, nil
 }
 
-// extractThreadTsFromPermalink extracts thread_ts from Slack permalink URL
-func (h *Handler) extractThreadTsFromPermalink(permalink string) string {
-	// Extract thread_ts from URL pattern like:
-	// https://workspace.slack.com/archives/C123/p1234567890123456?thread_ts=1234567890.123456
-	re := regexp.MustCompile(`[?&]thread_ts=([0-9.]+)`)
-	matches := re.FindStringSubmatch(permalink)
-	if len(matches) > 1 {
-		return matches[1]
+// validateSearchPaging validates the paging and sort parameters shared by search_messages and search_files
+func validateSearchPaging(count, page int, sort, sortDir string) error {
+	if count < 1 || count > 100 {
+		return fmt.Errorf("count must be between 1 and 100, got %d", count)
 	}
-	return ""
-}
-
-// extractWorkspaceURLFromPermalink extracts workspace URL from Slack permalink
-func (h *Handler) extractWorkspaceURLFromPermalink(permalink string) string {
-	// Extract workspace URL from permalink pattern like:
-	// https://workspace.slack.com/archives/C123/p1234567890123456
-	// Returns: https://workspace.slack.com
-	if permalink == "" {
-		return ""
+	if page < 1 || page > 100 {
+		return fmt.Errorf("page must be between 1 and 100, got %d", page)
 	}
-
-	re := regexp.MustCompile(`^(https?://[^/]+\.slack\.com)`)
-	matches := re.FindStringSubmatch(permalink)
-	if len(matches) > 1 {
-		return matches[1]
+	if sort != "score" && sort != "timestamp" {
+		return fmt.Errorf("sort must be 'score' or 'timestamp', got '%s'", sort)
+	}
+	if sortDir != "asc" && sortDir != "desc" {
+		return fmt.Errorf("sort_dir must be 'asc' or 'desc', got '%s'", sortDir)
 	}
-	return ""
+	return nil
 }
 
 // convertToSearchResponse converts Slack API response to our response format
@@ -227,15 +241,16 @@ func (h *Handler) convertToSearchResponse(result *slack.SearchMessages) *SearchM
 	}
 
 	if len(result.Matches) > 0 {
-		response.WorkspaceURL = h.extractWorkspaceURLFromPermalink(result.Matches[0].Permalink)
+		response.WorkspaceURL = permalink.ExtractWorkspaceURL(result.Matches[0].Permalink)
 	}
 
 	for _, match := range result.Matches {
 		msg := SearchMessage{
 			User:      match.User,
-			Text:      match.Text,
+			Text:      messageText(match.Text, match.Blocks),
 			Timestamp: match.Timestamp,
-			ThreadTs:  h.extractThreadTsFromPermalink(match.Permalink),
+			Permalink: match.Permalink,
+			ThreadTs:  permalink.ExtractThreadTs(match.Permalink),
 		}
 
 		if len(match.Attachments) > 0 {
@@ -8,6 +8,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_SearchMessages(t *testing.T) {
@@ -67,7 +68,7 @@ func TestHandler_SearchMessages(t *testing.T) {
 			Count:         50,
 			Page:          2,
 		}
-		mockClient.On("SearchMessages", expectedQuery, expectedParams).Return(mockResponse, nil)
+		mockClient.On("SearchMessages", mock.Anything, expectedQuery, expectedParams).Return(mockResponse, nil)
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -114,6 +115,7 @@ func TestHandler_SearchMessages(t *testing.T) {
 		assert.Equal(t, "U1234567", firstMsg["user"])
 		assert.Equal(t, "This is a test message", firstMsg["text"])
 		assert.Equal(t, "1234567890.123456", firstMsg["ts"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567890123456", firstMsg["permalink"])
 		assert.Nil(t, firstMsg["thread_ts"])
 
 		channel1 := firstMsg["channel"].(map[string]interface{})
@@ -134,6 +136,7 @@ func TestHandler_SearchMessages(t *testing.T) {
 		assert.Equal(t, "Another test message", secondMsg["text"])
 		assert.Equal(t, "1234567891.123456", secondMsg["ts"])
 		assert.Equal(t, "1234567890.123456", secondMsg["thread_ts"])
+		assert.Equal(t, "https://workspace.slack.com/archives/C1234567/p1234567891123456?thread_ts=1234567890.123456", secondMsg["permalink"])
 		assert.Nil(t, secondMsg["attachments"])
 
 		assert.Contains(t, messages, "pagination")
@@ -169,7 +172,7 @@ func TestHandler_SearchMessages(t *testing.T) {
 			Total: 0,
 		}
 
-		mockClient.On("SearchMessages", expectedQuery, expectedParams).Return(mockResponse, nil)
+		mockClient.On("SearchMessages", mock.Anything, expectedQuery, expectedParams).Return(mockResponse, nil)
 
 		handler := &Handler{
 			getClient: func(ctx context.Context) (SlackClient, error) {
@@ -205,6 +208,164 @@ func TestHandler_SearchMessages(t *testing.T) {
 
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("resolves channel ID to name for in_channel", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		expectedParams := slack.SearchParameters{
+			Sort:          "score",
+			SortDirection: "desc",
+			Count:         20,
+			Page:          1,
+		}
+		mockClient.On("SearchMessages", mock.Anything, "release in:general", expectedParams).Return(&slack.SearchMessages{}, nil)
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil)
+
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			channelRepository: channelRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_messages",
+				Arguments: map[string]interface{}{
+					"query":      "release",
+					"in_channel": "C1234567",
+				},
+			},
+		}
+
+		res, err := handler.SearchMessages(t.Context(), req)
+		assert.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("resolves mentions when resolve_mentions is true", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		mockResponse := &slack.SearchMessages{
+			Matches: []slack.SearchMessage{
+				{
+					User:      "U1234567",
+					Text:      "<@U2345678> please check <!subteam^S1234567|@sre>",
+					Timestamp: "1234567890.123456",
+					Permalink: "https://workspace.slack.com/archives/C1234567/p1234567890123456",
+				},
+			},
+		}
+		mockClient.On("SearchMessages", mock.Anything, "check", mock.Anything).Return(mockResponse, nil)
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane"}},
+		}, nil)
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_messages",
+				Arguments: map[string]interface{}{
+					"query":            "check",
+					"resolve_mentions": true,
+				},
+			},
+		}
+
+		res, err := handler.SearchMessages(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		matches := response["messages"].(map[string]interface{})["matches"].([]interface{})
+		assert.Equal(t, "@jane please check @sre", matches[0].(map[string]interface{})["text"])
+		assert.Equal(t, map[string]interface{}{"U2345678": "@jane", "S1234567": "@sre"}, response["mentions"])
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("adds author names when include_user_info is true", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		mockResponse := &slack.SearchMessages{
+			Matches: []slack.SearchMessage{
+				{User: "U1234567", Text: "first", Timestamp: "1234567890.123456"},
+				{User: "U1234567", Text: "second", Timestamp: "1234567891.123456"},
+				{User: "U9999999", Text: "unknown", Timestamp: "1234567892.123456"},
+			},
+		}
+		mockClient.On("SearchMessages", mock.Anything, "hello", mock.Anything).Return(mockResponse, nil)
+		// users.list is called once for the whole page even though U1234567 appears twice
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1234567", Name: "jdoe", Profile: slack.UserProfile{DisplayName: "john", RealName: "John Doe"}},
+		}, nil).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_messages",
+				Arguments: map[string]interface{}{
+					"query":             "hello",
+					"include_user_info": true,
+				},
+			},
+		}
+
+		res, err := handler.SearchMessages(t.Context(), req)
+		assert.NoError(t, err)
+
+		var response map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &response)
+		assert.NoError(t, err)
+
+		matches := response["messages"].(map[string]interface{})["matches"].([]interface{})
+		for _, i := range []int{0, 1} {
+			match := matches[i].(map[string]interface{})
+			assert.Equal(t, "john", match["user_name"])
+			assert.Equal(t, "John Doe", match["real_name"])
+		}
+		unknown := matches[2].(map[string]interface{})
+		assert.Equal(t, "U9999999", unknown["user"])
+		assert.NotContains(t, unknown, "user_name")
+		assert.NotContains(t, unknown, "real_name")
+
+		mockClient.AssertExpectations(t)
+	})
 }
 
 func TestHandler_buildSearchParams(t *testing.T) {
@@ -407,72 +568,3 @@ func TestHandler_buildSearchParams(t *testing.T) {
 		}
 	})
 }
-
-func TestHandler_ExtractThreadTsFromPermalink(t *testing.T) {
-	handler := &Handler{}
-	tests := []struct {
-		name      string
-		permalink string
-		expected  string
-	}{
-		{
-			name:      "Valid permalink with thread_ts",
-			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=1755823401.732729",
-			expected:  "1755823401.732729",
-		},
-		{
-			name:      "Permalink without thread_ts",
-			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329",
-			expected:  "",
-		},
-		{
-			name:      "Empty permalink",
-			permalink: "",
-			expected:  "",
-		},
-		{
-			name:      "Permalink with thread_ts using & separator",
-			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329?foo=bar&thread_ts=1755823401.732729",
-			expected:  "1755823401.732729",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := handler.extractThreadTsFromPermalink(tt.permalink)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestHandler_ExtractWorkspaceURLFromPermalink(t *testing.T) {
-	handler := &Handler{}
-	tests := []struct {
-		name      string
-		permalink string
-		expected  string
-	}{
-		{
-			name:      "Valid Slack URL with query parameters",
-			permalink: "https://workspace.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=1755823401.732729",
-			expected:  "https://workspace.slack.com",
-		},
-		{
-			name:      "Valid Slack URL with subdomain",
-			permalink: "https://my-company.slack.com/archives/C092E73H1A9/p1755857531080329",
-			expected:  "https://my-company.slack.com",
-		},
-		{
-			name:      "Empty permalink",
-			permalink: "",
-			expected:  "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := handler.extractWorkspaceURLFromPermalink(tt.permalink)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
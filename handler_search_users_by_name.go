@@ -4,36 +4,50 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// SearchUsersByName searches for users by display name
+// SearchUsersByName searches for users by display name, real name, email or title
 func (h *Handler) SearchUsersByName(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := h.getClient(ctx)
 	if err != nil {
 		return mcp.NewToolResultError(ErrSlackTokenNotConfigured), nil
 	}
 
-	displayName := request.GetString("display_name", "")
-	if displayName == "" {
-		return mcp.NewToolResultError("display_name is required"), nil
+	// display_name is the original name of the query parameter and is still accepted
+	query := request.GetString("query", request.GetString("display_name", ""))
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	field := UserSearchField(request.GetString("field", string(UserSearchFieldDisplayName)))
+	if field != UserSearchFieldAny && !slices.Contains(userSearchFields, field) {
+		return mcp.NewToolResultError(fmt.Sprintf("field must be one of display_name, real_name, email, title or any, got '%s'", field)), nil
+	}
+	mode := UserMatchExact
+	switch {
+	case request.GetBool("fuzzy", false):
+		mode = UserMatchFuzzy
+	case !request.GetBool("exact", true):
+		mode = UserMatchPartial
 	}
-	exact := request.GetBool("exact", true)
 
-	users, err := h.userRepository.FindByDisplayName(ctx, client, displayName, exact)
+	matches, err := h.userRepository.SearchUsers(ctx, client, query, field, mode)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Convert slack.User to UserProfile
 	var profiles []UserProfile
-	for _, user := range users {
+	for _, match := range matches {
 		profiles = append(profiles, UserProfile{
-			UserID:      user.ID,
-			DisplayName: user.Profile.DisplayName,
-			RealName:    user.Profile.RealName,
-			Email:       user.Profile.Email,
+			UserID:       match.User.ID,
+			DisplayName:  match.User.Profile.DisplayName,
+			RealName:     match.User.Profile.RealName,
+			Email:        match.User.Profile.Email,
+			Title:        match.User.Profile.Title,
+			MatchedField: string(match.Field),
 		})
 	}
 
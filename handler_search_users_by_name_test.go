@@ -186,4 +186,75 @@ func TestHandler_SearchUsersByName(t *testing.T) {
 
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("can search by any field and reports the matched field", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		users := []slack.User{
+			{ID: "U1234567", Profile: slack.UserProfile{RealName: "Taro Yamada", Email: "taro@example.com", Title: "SRE"}},
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "taro", RealName: "Taro Suzuki"}},
+		}
+		mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return(users, nil)
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return mockClient, nil
+			},
+			userRepository: userRepo,
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_users_by_name",
+				Arguments: map[string]interface{}{
+					"query": "Taro",
+					"field": "any",
+					"exact": false,
+				},
+			},
+		}
+
+		res, err := handler.SearchUsersByName(t.Context(), req)
+		assert.NoError(t, err)
+
+		var profiles []map[string]interface{}
+		err = json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &profiles)
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{
+			{"user_id": "U2345678", "display_name": "taro", "real_name": "Taro Suzuki", "matched_field": "display_name"},
+			{"user_id": "U1234567", "real_name": "Taro Yamada", "email": "taro@example.com", "title": "SRE", "matched_field": "real_name"},
+		}, profiles)
+	})
+
+	t.Run("returns error with unknown field", func(t *testing.T) {
+		handler := &Handler{
+			getClient: func(ctx context.Context) (SlackClient, error) {
+				return &SlackClientMock{}, nil
+			},
+		}
+
+		req := mcp.CallToolRequest{
+			Params: struct {
+				Name      string    `json:"name"`
+				Arguments any       `json:"arguments,omitempty"`
+				Meta      *mcp.Meta `json:"_meta,omitempty"`
+			}{
+				Name: "search_users_by_name",
+				Arguments: map[string]interface{}{
+					"query": "taro",
+					"field": "phone",
+				},
+			},
+		}
+
+		res, err := handler.SearchUsersByName(t.Context(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Equal(t, "field must be one of display_name, real_name, email, title or any, got 'phone'", res.Content[0].(mcp.TextContent).Text)
+	})
 }
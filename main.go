@@ -35,14 +35,12 @@ func main() {
 		mcp.NewTool("search_messages",
 			mcp.WithDescription(`Search for messages with specific criteria/filters. Use this when: 1) You need to find messages from a specific user, 2) You need messages from a specific date range, 3) You need to search by keywords, 4) You want to filter by channel. This tool is optimized for targeted searches.
 
-Note: Response includes workspace_url, channel.id, and ts (timestamp) which can be used to construct Slack permalinks:
-- Regular message (no thread_ts field): {workspace_url}/archives/{channel.id}/p{ts without dot}
-- Thread reply (has thread_ts field): Same URL with ?thread_ts={thread_ts}&channel={channel.id}&message_ts={ts}`),
+Note: Each message includes a ready-made permalink field. Use it as-is when citing messages.`),
 			mcp.WithString("query",
 				mcp.Description("Basic search query text only. Do NOT include modifiers like 'from:', 'in:', etc. - use the dedicated fields instead."),
 			),
 			mcp.WithString("in_channel",
-				mcp.Description("Search within a specific channel. Specify the channel name (e.g., 'general', 'random', 'チーム-dev')."),
+				mcp.Description("Search within a specific channel. Specify the channel name (e.g., 'general', '#random', 'チーム-dev') or channel ID (e.g., 'C1234567')."),
 			),
 			mcp.WithString("from_user",
 				mcp.Description("Search for messages from a specific user. Must be a Slack user ID (e.g., 'U1234567')."),
@@ -99,6 +97,14 @@ Note: Response includes workspace_url, channel.id, and ts (timestamp) which can
 				),
 				mcp.Description("Search for messages where the authenticated user has specific emoji reactions. Only emoji codes are supported (e.g., [\":eyes:\", \":fire:\"]). Emoji codes must be wrapped with colons (e.g., \":eyes:\"). Multiple emoji reactions can be specified."),
 			),
+			mcp.WithBoolean("resolve_mentions",
+				mcp.Description("If true, rewrites mention tokens in message text (e.g., <@U1234567>, <#C1234567|dev>, <!subteam^S1234567>, <!here>) into readable forms (@display_name, #channel, @group, @here) and returns a mentions map from each ID to its readable form (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithBoolean("include_user_info",
+				mcp.Description("If true, adds the author's user_name and real_name to each message so get_user_profiles is not needed (default: false)"),
+				mcp.DefaultBool(false),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -111,13 +117,10 @@ Note: Response includes workspace_url, channel.id, and ts (timestamp) which can
 		mcp.NewTool("get_thread_replies",
 			mcp.WithDescription(`Get all replies in a message thread
 
-Note: To construct Slack permalinks from the response:
-- Use workspace_url from search_messages tool response
-- Thread reply URL: {workspace_url}/archives/{channel_id}/p{ts without dot}?thread_ts={thread_ts}&channel={channel_id}&message_ts={ts}
-Where channel_id and thread_ts are the values provided as input parameters`),
+Note: Each message includes a ready-made permalink field. Use it as-is when citing messages.`),
 			mcp.WithString("channel_id",
 				mcp.Required(),
-				mcp.Description("The ID of the channel containing the thread (e.g., 'C1234567')"),
+				mcp.Description("The conversation containing the thread. Specify the channel ID (public or private channel 'C1234567', legacy private channel or group DM 'G1234567', DM 'D1234567') or channel name (e.g., 'general', '#general')"),
 			),
 			mcp.WithString("thread_ts",
 				mcp.Required(),
@@ -129,6 +132,21 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 			mcp.WithString("cursor",
 				mcp.Description("Pagination cursor for next page of results"),
 			),
+			mcp.WithBoolean("fetch_all",
+				mcp.Description("If true, follows next_cursor automatically and returns the whole thread in one response, up to max_messages. limit is ignored in this mode (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithNumber("max_messages",
+				mcp.Description("Maximum number of messages to collect when fetch_all is true (1-10000, default: 1000). If the thread is longer, truncated is set to true"),
+			),
+			mcp.WithBoolean("resolve_mentions",
+				mcp.Description("If true, rewrites mention tokens in message text (e.g., <@U1234567>, <#C1234567|dev>, <!subteam^S1234567>, <!here>) into readable forms (@display_name, #channel, @group, @here) and returns a mentions map from each ID to its readable form (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithBoolean("include_user_info",
+				mcp.Description("If true, adds the author's user_name and real_name to each message so get_user_profiles is not needed (default: false)"),
+				mcp.DefaultBool(false),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -136,10 +154,57 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 		handler.GetThreadReplies,
 	)
 
+	// Add get_channel_history tool
+	s.AddTool(
+		mcp.NewTool("get_channel_history",
+			mcp.WithDescription(`Get messages posted to a channel in reverse chronological order. Use this when you want to read what happened in a channel during a time range without relying on keywords.
+
+Note: Messages with reply_count are thread parents. Use get_thread_replies with the same channel_id and the message ts as thread_ts to read the thread. Each message includes a ready-made permalink field.`),
+			mcp.WithString("channel_id",
+				mcp.Required(),
+				mcp.Description("The channel to read. Specify the channel ID (e.g., 'C1234567') or channel name (e.g., 'general', '#general')"),
+			),
+			mcp.WithString("oldest",
+				mcp.Description("Only messages after this timestamp in format '1234567890.123456'"),
+			),
+			mcp.WithString("latest",
+				mcp.Description("Only messages before this timestamp in format '1234567890.123456'"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of messages to retrieve (1-1000, default: 100)"),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("Pagination cursor for next page of results"),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.GetChannelHistory,
+	)
+
+	// Add open_permalink tool
+	s.AddTool(
+		mcp.NewTool("open_permalink",
+			mcp.WithDescription("Open a Slack message permalink (e.g., https://workspace.slack.com/archives/C1234567/p1234567890123456) and get the referenced message. When the message belongs to a thread, the thread's parent and replies are returned as context."),
+			mcp.WithString("url",
+				mcp.Required(),
+				mcp.Description("Slack message permalink. Links with thread_ts, cid or message_ts query parameters and enterprise grid domains are supported."),
+			),
+			mcp.WithNumber("thread_limit",
+				mcp.Description("Number of thread messages to include as context (1-1000, default: 100)"),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.OpenPermalink,
+	)
+
 	// Add get_user_profiles tool
 	s.AddTool(
 		mcp.NewTool("get_user_profiles",
-			mcp.WithDescription("Get multiple users profile information in bulk"),
+			mcp.WithDescription("Get multiple users profile information in bulk. Profiles may come from a user list cached for up to 30 minutes, except when 'status' is requested, which is always fetched fresh."),
 			mcp.WithArray("user_ids",
 				mcp.Required(),
 				mcp.Items(
@@ -149,6 +214,14 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 				),
 				mcp.Description("Array of user IDs to retrieve profiles for (e.g., ['U1234567', 'U2345678']). Maximum 100 user IDs."),
 			),
+			mcp.WithArray("fields",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Optional fields to add to user_id, display_name, real_name and email: 'title', 'timezone' (tz, tz_offset), 'status' (status_text, status_emoji, status_expiration), 'is_bot', 'is_admin', 'deleted', 'is_restricted' (guest), 'custom_fields' (workspace-defined fields with labels) or 'all'. Select only what you need to keep responses small (default: none)"),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -156,18 +229,46 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 		handler.GetUserProfiles,
 	)
 
+	// Add lookup_users_by_email tool
+	s.AddTool(
+		mcp.NewTool("lookup_users_by_email",
+			mcp.WithDescription("Look up users by email address in bulk. Returns the same basic profile fields as get_user_profiles, with an error for each email that has no user."),
+			mcp.WithArray("emails",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Array of email addresses to look up (e.g., ['jane@example.com']). Matching is case-insensitive. Maximum 100 emails."),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.LookupUsersByEmail,
+	)
+
 	// Add search_users_by_name tool
 	s.AddTool(
 		mcp.NewTool("search_users_by_name",
-			mcp.WithDescription("Search users by display name"),
+			mcp.WithDescription("Search users by display name, real name, email or title. Matching ignores case, full-width/half-width forms, katakana/hiragana differences and spaces. Results are ranked with exact matches first, then prefix, substring and fuzzy matches, and each result reports the field that matched."),
+			mcp.WithString("query",
+				mcp.Description("The text to search for. Required unless display_name is given"),
+			),
 			mcp.WithString("display_name",
-				mcp.Required(),
-				mcp.Description("The display name to search for"),
+				mcp.Description("Deprecated alias of query"),
+			),
+			mcp.WithString("field",
+				mcp.Description("Field to search: 'display_name', 'real_name', 'email', 'title' or 'any'. Use 'any' for colleagues who have not set a display name (default: 'display_name')"),
 			),
 			mcp.WithBoolean("exact",
-				mcp.Description("If true (default), performs exact match. If false, performs partial match"),
+				mcp.Description("If true (default), performs exact match. If false, also returns prefix and substring matches"),
 				mcp.DefaultBool(true),
 			),
+			mcp.WithBoolean("fuzzy",
+				mcp.Description("If true, also returns names within a small edit distance of the query to tolerate typos and spelling variants. Implies exact=false (default: false)"),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -175,6 +276,65 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 		handler.SearchUsersByName,
 	)
 
+	// Add list_user_groups tool
+	s.AddTool(
+		mcp.NewTool("list_user_groups",
+			mcp.WithDescription("List user groups (e.g., @platform-team). Use this to find a group by handle or name before getting its members."),
+			mcp.WithString("query",
+				mcp.Description("Filter user groups whose handle or name contains this text (case-insensitive, e.g., 'sre' or '@platform')"),
+			),
+			mcp.WithBoolean("include_disabled",
+				mcp.Description("If true, includes disabled user groups (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.ListUserGroups,
+	)
+
+	// Add get_user_group_members tool
+	s.AddTool(
+		mcp.NewTool("get_user_group_members",
+			mcp.WithDescription("Get the member user IDs of a user group. The IDs can be used with search_messages' from_user and with, or get_user_profiles."),
+			mcp.WithString("user_group",
+				mcp.Required(),
+				mcp.Description("User group handle (e.g., '@platform-team' or 'platform-team') or ID (e.g., 'S1234567')"),
+			),
+			mcp.WithBoolean("include_user_info",
+				mcp.Description("If true, adds each member's user_name and real_name so get_user_profiles is not needed (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.GetUserGroupMembers,
+	)
+
+	// Add list_channels tool
+	s.AddTool(
+		mcp.NewTool("list_channels",
+			mcp.WithDescription("List public and private channels visible to the user. Use this to find the exact channel name or ID before searching or reading a channel."),
+			mcp.WithString("name",
+				mcp.Description("Filter channels whose name contains this text (case-insensitive, e.g., 'dev')"),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("If true, includes archived channels (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithBoolean("member_only",
+				mcp.Description("If true, returns only channels the user is a member of (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.ListChannels,
+	)
+
 	// Add search_files tool
 	s.AddTool(
 		mcp.NewTool("search_files",
@@ -191,11 +351,35 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 				mcp.Description("File types to filter by (e.g., ['canvases', 'pdfs']). Available types: lists, canvases, documents, emails, images, pdfs, presentations, snippets, spreadsheets, audio, videos"),
 			),
 			mcp.WithString("in_channel",
-				mcp.Description("Search within a specific channel. Specify the channel name (e.g., 'general', 'random', 'チーム-dev')."),
+				mcp.Description("Search within a specific channel. Specify the channel name (e.g., 'general', '#random', 'チーム-dev') or channel ID (e.g., 'C1234567')."),
+			),
+			mcp.WithArray("in_channels",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Search within any of these channels. Specify channel names or channel IDs (max 10 together with in_channel). Each channel is searched separately and the results are merged, so only page 1 is available when more than one channel is given."),
+			),
+			mcp.WithArray("exclude_channels",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Exclude files in these channels. Specify channel names or channel IDs."),
 			),
 			mcp.WithString("from_user",
 				mcp.Description("Search for files from a specific user. Must be a Slack user ID (e.g., 'U1234567')."),
 			),
+			mcp.WithArray("exclude_users",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Exclude files from these users. Must be Slack user IDs (e.g., ['U1234567'])."),
+			),
 			mcp.WithArray("with_users",
 				mcp.Items(
 					map[string]interface{}{
@@ -213,12 +397,29 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 			mcp.WithString("on",
 				mcp.Description("Search for files on this specific date (YYYY-MM-DD)"),
 			),
+			mcp.WithArray("is",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Restrict to files you have marked. Available values: 'saved', 'starred'"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Search result sort method: 'score' or 'timestamp' (default: 'timestamp')"),
+			),
+			mcp.WithString("sort_dir",
+				mcp.Description("Sort direction: 'asc' or 'desc' (default: 'desc')"),
+			),
 			mcp.WithNumber("count",
 				mcp.Description("Number of results per page (1-100, default: 20)"),
 			),
 			mcp.WithNumber("page",
 				mcp.Description("Page number of results (1-100, default: 1)"),
 			),
+			mcp.WithBoolean("include_preview",
+				mcp.Description("Include a preview snippet of each file's content, when Slack provides one (default: false)"),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -229,7 +430,7 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 	// Add get_canvas_content tool
 	s.AddTool(
 		mcp.NewTool("get_canvas_content",
-			mcp.WithDescription("Get HTML content of Slack canvases by their IDs."),
+			mcp.WithDescription("Get content of Slack canvases by their IDs. Use format 'markdown' or 'text' to save tokens."),
 			mcp.WithArray("canvas_ids",
 				mcp.Required(),
 				mcp.Items(
@@ -239,6 +440,21 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 				),
 				mcp.Description("Array of canvas file IDs to retrieve content for (e.g., ['F1234567', 'F2345678']). Maximum 20 canvas IDs."),
 			),
+			mcp.WithString("format",
+				mcp.Description("Output format: 'html' (cleaned HTML), 'markdown' (GitHub-flavored Markdown) or 'text' (plain text) (default: 'html')"),
+			),
+			mcp.WithBoolean("outline",
+				mcp.Description("If true, returns only the heading tree of each canvas with section IDs and sizes (characters in the requested format) instead of the content. Use it to pick sections of large canvases (default: false)"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithArray("sections",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Section IDs from outline to retrieve. Each section includes its subsections. Content before the first heading has the ID 'intro'"),
+			),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -246,6 +462,62 @@ Where channel_id and thread_ts are the values provided as input parameters`),
 		handler.GetCanvasContent,
 	)
 
+	// Add get_file_content tool
+	s.AddTool(
+		mcp.NewTool("get_file_content",
+			mcp.WithDescription("Get the text content of a Slack file such as a snippet, post, email, CSV or plain text file. Output is capped by limit and max_chars; when truncated is true, continue from end_line + 1 (end_line may be partial when max_chars cut it). Binary files are not supported; use get_canvas_content for canvases and get_document_content for PDF and Office documents."),
+			mcp.WithString("file_id",
+				mcp.Required(),
+				mcp.Description("File ID to retrieve content for (e.g., 'F1234567')"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to return, starting at 1 (default: 1)"),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to return, inclusive (default: the last line of the file)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of lines to return. The response has truncated set to true when lines are left out (1-10000, default: 1000)"),
+			),
+			mcp.WithNumber("max_chars",
+				mcp.Description("Maximum number of characters of text to return. The response has truncated set to true when the text is cut (1-200000, default: 50000)"),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.GetFileContent,
+	)
+
+	// Add get_document_content tool
+	s.AddTool(
+		mcp.NewTool("get_document_content",
+			mcp.WithDescription("Extract the text of a PDF, DOCX, XLSX or PPTX file. PDFs are split into pages, XLSX files into sheets with tab-separated cells and PPTX files into slides. Scanned PDFs without a text layer return empty text."),
+			mcp.WithString("file_id",
+				mcp.Required(),
+				mcp.Description("File ID to extract text from (e.g., 'F1234567')"),
+			),
+			mcp.WithString("pages",
+				mcp.Description("Pages of a PDF or slides of a PPTX to return, such as '1-3,5' (default: all)"),
+			),
+			mcp.WithArray("sheets",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Sheet names of an XLSX to return (default: all)"),
+			),
+			mcp.WithNumber("max_chars",
+				mcp.Description("Maximum number of characters of text to return. The response has truncated set to true when the text is cut (1-200000, default: 50000)"),
+			),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		),
+		handler.GetDocumentContent,
+	)
+
 	transport := os.Getenv("TRANSPORT")
 	if transport == "" {
 		transport = "stdio"
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches Slack mention tokens such as <@U123>, <@U123|name>, <#C123|dev>, <!subteam^S123|@team> and <!here>
+var mentionPattern = regexp.MustCompile(`<([@#!])([^>|]+)(?:\|([^>]*))?>`)
+
+// resolveMentions rewrites mention tokens in the given texts into human-readable forms in place.
+// It returns a map from each raw ID to its rewritten form for traceability, e.g. {"U1234567": "@john"}.
+// The map is keyed by ID because different users can share a display name.
+// Lookups are best-effort: tokens that cannot be resolved keep a readable fallback.
+func (h *Handler) resolveMentions(ctx context.Context, client SlackClient, texts []*string) map[string]string {
+	var userIDs, channelIDs, userGroupIDs []string
+	for _, text := range texts {
+		for _, match := range mentionPattern.FindAllStringSubmatch(*text, -1) {
+			switch match[1] {
+			case "@":
+				userIDs = append(userIDs, match[2])
+			case "#":
+				if match[3] == "" {
+					channelIDs = append(channelIDs, match[2])
+				}
+			case "!":
+				// Tokens rendered from rich_text blocks carry no label, so the handle has to be looked up
+				if id, ok := strings.CutPrefix(match[2], "subteam^"); ok && match[3] == "" {
+					userGroupIDs = append(userGroupIDs, id)
+				}
+			}
+		}
+	}
+
+	userNames := make(map[string]string)
+	if len(userIDs) > 0 {
+		users, err := h.userRepository.FindByIDs(ctx, client, userIDs)
+		if err != nil {
+			slog.Debug("failed to look up users for mentions", "error", err)
+		}
+		for id, user := range users {
+			userNames[id] = userDisplayName(user.Profile.DisplayName, user.Profile.RealName, user.Name)
+		}
+	}
+
+	channelNames := make(map[string]string)
+	for _, id := range channelIDs {
+		if _, ok := channelNames[id]; ok {
+			continue
+		}
+		channel, err := h.channelRepository.FindByID(ctx, client, id)
+		if err != nil {
+			slog.Debug("failed to look up channel for mentions", "error", err)
+			break
+		}
+		if channel != nil {
+			channelNames[id] = channel.Name
+		}
+	}
+
+	userGroupHandles := make(map[string]string)
+	if h.userGroupRepository != nil {
+		for _, id := range userGroupIDs {
+			if _, ok := userGroupHandles[id]; ok {
+				continue
+			}
+			group, err := h.userGroupRepository.FindByRef(ctx, client, id)
+			if err != nil {
+				slog.Debug("failed to look up user group for mentions", "error", err)
+				break
+			}
+			if group != nil {
+				userGroupHandles[id] = group.Handle
+			}
+		}
+	}
+
+	mentions := make(map[string]string)
+	for _, text := range texts {
+		*text = expandMentions(*text, userNames, channelNames, userGroupHandles, mentions)
+	}
+	return mentions
+}
+
+// expandMentions replaces mention tokens in text using the given ID-to-name maps, recording each replacement in mentions
+func expandMentions(text string, userNames, channelNames, userGroupHandles map[string]string, mentions map[string]string) string {
+	return mentionPattern.ReplaceAllStringFunc(text, func(token string) string {
+		match := mentionPattern.FindStringSubmatch(token)
+		kind, id, label := match[1], match[2], match[3]
+
+		var readable string
+		switch kind {
+		case "@":
+			name := userNames[id]
+			if name == "" {
+				name = label
+			}
+			if name == "" {
+				return token
+			}
+			readable = "@" + name
+		case "#":
+			name := label
+			if name == "" {
+				name = channelNames[id]
+			}
+			if name == "" {
+				return token
+			}
+			readable = "#" + name
+		case "!":
+			if subteamID, ok := strings.CutPrefix(id, "subteam^"); ok {
+				switch {
+				case label != "":
+					readable = "@" + strings.TrimPrefix(label, "@")
+				case userGroupHandles[subteamID] != "":
+					readable = "@" + userGroupHandles[subteamID]
+				default:
+					readable = "@" + subteamID
+				}
+				id = subteamID
+			} else {
+				switch id {
+				case "here", "channel", "everyone":
+					return "@" + id
+				}
+				// Other commands such as <!date^...|fallback> are replaced by their fallback text
+				if label != "" {
+					return label
+				}
+				return token
+			}
+		}
+
+		mentions[id] = readable
+		return readable
+	})
+}
+
+// userDisplayName picks the most human-friendly name available for a user
+func userDisplayName(displayName, realName, name string) string {
+	if displayName != "" {
+		return displayName
+	}
+	if realName != "" {
+		return realName
+	}
+	return name
+}
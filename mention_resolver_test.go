@@ -0,0 +1,177 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpandMentions(t *testing.T) {
+	userNames := map[string]string{"U1234567": "john"}
+	channelNames := map[string]string{"C1234567": "general"}
+	userGroupHandles := map[string]string{"S2345678": "design"}
+
+	tests := []struct {
+		name             string
+		text             string
+		expected         string
+		expectedMentions map[string]string
+	}{
+		{
+			name:             "user mention",
+			text:             "hi <@U1234567>",
+			expected:         "hi @john",
+			expectedMentions: map[string]string{"U1234567": "@john"},
+		},
+		{
+			name:             "unknown user mention with label",
+			text:             "hi <@U9999999|jane>",
+			expected:         "hi @jane",
+			expectedMentions: map[string]string{"U9999999": "@jane"},
+		},
+		{
+			name:             "unknown user mention keeps token",
+			text:             "hi <@U9999999>",
+			expected:         "hi <@U9999999>",
+			expectedMentions: map[string]string{},
+		},
+		{
+			name:             "channel mention with label",
+			text:             "see <#C7654321|dev>",
+			expected:         "see #dev",
+			expectedMentions: map[string]string{"C7654321": "#dev"},
+		},
+		{
+			name:             "channel mention without label",
+			text:             "see <#C1234567>",
+			expected:         "see #general",
+			expectedMentions: map[string]string{"C1234567": "#general"},
+		},
+		{
+			name:             "subteam mention with and without label",
+			text:             "<!subteam^S1234567|@sre> and <!subteam^S7654321>",
+			expected:         "@sre and @S7654321",
+			expectedMentions: map[string]string{"S1234567": "@sre", "S7654321": "@S7654321"},
+		},
+		{
+			name:             "subteam mention without label uses the looked-up handle",
+			text:             "cc <!subteam^S2345678>",
+			expected:         "cc @design",
+			expectedMentions: map[string]string{"S2345678": "@design"},
+		},
+		{
+			name:             "special mentions",
+			text:             "<!here> <!channel> <!everyone>",
+			expected:         "@here @channel @everyone",
+			expectedMentions: map[string]string{},
+		},
+		{
+			name:             "date command uses fallback text",
+			text:             "due <!date^1392734382^{date_short}|Feb 18, 2014>",
+			expected:         "due Feb 18, 2014",
+			expectedMentions: map[string]string{},
+		},
+		{
+			name:             "text without mentions",
+			text:             "plain <https://example.com|link>",
+			expected:         "plain <https://example.com|link>",
+			expectedMentions: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mentions := map[string]string{}
+			result := expandMentions(tt.text, userNames, channelNames, userGroupHandles, mentions)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedMentions, mentions)
+		})
+	}
+}
+
+func TestHandler_resolveMentions(t *testing.T) {
+	t.Run("resolves users and channels through repositories", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1234567", Name: "jdoe", Profile: slack.UserProfile{DisplayName: "john"}},
+			{ID: "U2345678", Name: "jsmith", Profile: slack.UserProfile{RealName: "Jane Smith"}},
+		}, nil).Once()
+		general := newTestChannel("C1234567", "general", false, true)
+		mockClient.On("GetConversationInfo", mock.Anything, "C1234567").Return(&general, nil).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		channelRepo := NewChannelRepository()
+		t.Cleanup(channelRepo.Close)
+		handler := &Handler{userRepository: userRepo, channelRepository: channelRepo}
+
+		text1 := "<@U1234567> posted in <#C1234567>"
+		text2 := "thanks <@U2345678>"
+		mentions := handler.resolveMentions(t.Context(), mockClient, []*string{&text1, &text2})
+
+		assert.Equal(t, "@john posted in #general", text1)
+		assert.Equal(t, "thanks @Jane Smith", text2)
+		assert.Equal(t, map[string]string{
+			"U1234567": "@john",
+			"U2345678": "@Jane Smith",
+			"C1234567": "#general",
+		}, mentions)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("resolves user group handles for tokens without a label", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", mock.Anything).Return([]slack.UserGroup{
+			{ID: "S1234567", Handle: "sre"},
+		}, nil).Once()
+
+		userGroupRepo := NewUserGroupRepository()
+		t.Cleanup(userGroupRepo.Close)
+		handler := &Handler{userGroupRepository: userGroupRepo}
+
+		text := "cc <!subteam^S1234567> and <!subteam^S9999999>"
+		mentions := handler.resolveMentions(t.Context(), mockClient, []*string{&text})
+
+		assert.Equal(t, "cc @sre and @S9999999", text)
+		assert.Equal(t, map[string]string{
+			"S1234567": "@sre",
+			"S9999999": "@S9999999",
+		}, mentions)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("keeps every user that shares a display name", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return([]slack.User{
+			{ID: "U1234567", Profile: slack.UserProfile{DisplayName: "john"}},
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "john"}},
+		}, nil).Once()
+
+		userRepo := NewUserRepository()
+		t.Cleanup(userRepo.Close)
+		handler := &Handler{userRepository: userRepo}
+
+		text := "<@U1234567> and <@U2345678>"
+		mentions := handler.resolveMentions(t.Context(), mockClient, []*string{&text})
+
+		assert.Equal(t, "@john and @john", text)
+		assert.Equal(t, map[string]string{
+			"U1234567": "@john",
+			"U2345678": "@john",
+		}, mentions)
+	})
+
+	t.Run("does not call API when there are no mentions to look up", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		handler := &Handler{}
+
+		text := "hello <!here>"
+		mentions := handler.resolveMentions(t.Context(), mockClient, []*string{&text})
+
+		assert.Equal(t, "hello @here", text)
+		assert.Empty(t, mentions)
+		mockClient.AssertExpectations(t)
+	})
+}
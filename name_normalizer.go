@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeName folds a name for comparison so that the ways people type the same name compare equal.
+// It applies NFKC (fullwidth ASCII, halfwidth katakana, separate sound marks and the like), folds case,
+// folds katakana to hiragana and drops whitespace, so "ﾔﾏﾀﾞ　Taro" and "やまだtaro" are the same.
+func normalizeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		// NFKC turns spacing sound marks into a space and a combining mark, which would not compose with the preceding kana
+		case r == 0x309B:
+			return 0x3099
+		case r == 0x309C:
+			return 0x309A
+		}
+		return r
+	}, s)
+
+	// A Caser keeps state and must not be shared between goroutines, so each call creates its own
+	s = cases.Fold().String(norm.NFKC.String(s))
+
+	return strings.Map(func(r rune) rune {
+		// Katakana U+30A1 to U+30F6 line up with hiragana U+3041 to U+3096
+		if r >= 0x30A1 && r <= 0x30F6 {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// maxFuzzyDistance is the number of edits allowed for a query of n runes.
+// Short queries allow none, since one edit to a two-character Japanese name matches almost anything.
+func maxFuzzyDistance(n int) int {
+	switch {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	}
+	return 2
+}
+
+// fuzzyDistance returns the smallest Levenshtein distance between query and any substring of value
+func fuzzyDistance(query, value []rune) int {
+	// prev[i] is the distance between query[:i] and the best substring of value ending at the previous rune
+	prev := make([]int, len(query)+1)
+	cur := make([]int, len(query)+1)
+	for i := range prev {
+		prev[i] = i
+	}
+
+	best := prev[len(query)]
+	for _, c := range value {
+		cur[0] = 0
+		for i := 1; i <= len(query); i++ {
+			cost := 1
+			if query[i-1] == c {
+				cost = 0
+			}
+			cur[i] = min(prev[i]+1, cur[i-1]+1, prev[i-1]+cost)
+		}
+		best = min(best, cur[len(query)])
+		prev, cur = cur, prev
+	}
+	return best
+}
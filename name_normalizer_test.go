@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Taro Yamada", "taroyamada"},
+		{"Ｔａｒｏ１２３", "taro123"},
+		{"ﾔﾏﾀﾞ ﾀﾛｳ", "やまだたろう"},
+		{"ヤマダ　タロウ", "やまだたろう"},
+		{"ﾊﾟﾝﾀﾞ", "ぱんだ"},
+		{"ｳﾞｨ", "ゔぃ"},
+		{"が", "が"},
+		{"山田 太郎", "山田太郎"},
+		{"か゛", "が"},
+		{"①ﬁ", "1fi"},
+		{"㈱山田", "(株)山田"},
+		{"Straße", "strasse"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			// Handlers normalize names from several goroutines at once
+			t.Parallel()
+			assert.Equal(t, tc.expected, normalizeName(tc.input))
+		})
+	}
+}
+
+func TestFuzzyDistance(t *testing.T) {
+	testCases := []struct {
+		query    string
+		value    string
+		expected int
+	}{
+		{"abc", "abc", 0},
+		{"abc", "xxabcxx", 0},
+		{"abc", "xxabxx", 1},
+		{"やまたたろう", "やまだたろう", 1},
+		{"abc", "", 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query+"/"+tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fuzzyDistance([]rune(tc.query), []rune(tc.value)))
+		})
+	}
+}
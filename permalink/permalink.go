@@ -0,0 +1,123 @@
+// Package permalink parses and builds Slack message permalinks.
+package permalink
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	threadTsPattern     = regexp.MustCompile(`[?&]thread_ts=([0-9.]+)`)
+	archivesPathPattern = regexp.MustCompile(`^/archives/([CGD][A-Z0-9]+)/p(\d{10})(\d{6})/?$`)
+	tsPattern           = regexp.MustCompile(`^\d{10}\.\d{6}$`)
+)
+
+// Permalink is a parsed Slack message permalink
+type Permalink struct {
+	WorkspaceURL string
+	ChannelID    string
+	// Timestamp is the ts of the linked message
+	Timestamp string
+	// ThreadTs is the ts of the thread parent. Empty when the link does not point into a thread.
+	ThreadTs string
+}
+
+// IsThreadReply reports whether the permalink points to a reply inside a thread
+func (p *Permalink) IsThreadReply() bool {
+	return p.ThreadTs != "" && p.ThreadTs != p.Timestamp
+}
+
+// Parse parses a Slack permalink such as
+// https://workspace.slack.com/archives/C123/p1234567890123456?thread_ts=1234567890.123456&cid=C123
+func Parse(rawURL string) (*Permalink, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid permalink: %w", err)
+	}
+
+	workspaceURL := workspaceURLOf(u)
+	if workspaceURL == "" {
+		return nil, fmt.Errorf("invalid permalink: host must be a slack.com workspace domain")
+	}
+
+	matches := archivesPathPattern.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid permalink: path must be /archives/{channel_id}/p{timestamp}")
+	}
+
+	p := &Permalink{
+		WorkspaceURL: workspaceURL,
+		ChannelID:    matches[1],
+		Timestamp:    matches[2] + "." + matches[3],
+	}
+
+	query := u.Query()
+	if threadTs := query.Get("thread_ts"); threadTs != "" {
+		if !tsPattern.MatchString(threadTs) {
+			return nil, fmt.Errorf("invalid permalink: thread_ts must be in format '1234567890.123456'")
+		}
+		p.ThreadTs = threadTs
+	}
+	if messageTs := query.Get("message_ts"); messageTs != "" {
+		if !tsPattern.MatchString(messageTs) {
+			return nil, fmt.Errorf("invalid permalink: message_ts must be in format '1234567890.123456'")
+		}
+		p.Timestamp = messageTs
+	}
+
+	return p, nil
+}
+
+// Build builds a Slack message permalink from its parts.
+// When threadTs differs from ts, the message is a thread reply and the link opens it inside the thread.
+func Build(workspaceURL, channelID, ts, threadTs string) string {
+	if workspaceURL == "" || channelID == "" || ts == "" {
+		return ""
+	}
+
+	link := fmt.Sprintf("%s/archives/%s/p%s", workspaceURL, channelID, strings.ReplaceAll(ts, ".", ""))
+	if threadTs != "" && threadTs != ts {
+		link += fmt.Sprintf("?thread_ts=%s&cid=%s", threadTs, channelID)
+	}
+	return link
+}
+
+// ExtractThreadTs extracts thread_ts from a Slack permalink, or returns "" when absent
+func ExtractThreadTs(permalink string) string {
+	// Extract thread_ts from URL pattern like:
+	// https://workspace.slack.com/archives/C123/p1234567890123456?thread_ts=1234567890.123456
+	matches := threadTsPattern.FindStringSubmatch(permalink)
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
+
+// ExtractWorkspaceURL extracts the workspace URL from a Slack permalink
+func ExtractWorkspaceURL(permalink string) string {
+	// Extract workspace URL from permalink pattern like:
+	// https://workspace.slack.com/archives/C123/p1234567890123456
+	// Returns: https://workspace.slack.com
+	if permalink == "" {
+		return ""
+	}
+
+	u, err := url.Parse(strings.TrimSpace(permalink))
+	if err != nil {
+		return ""
+	}
+	return workspaceURLOf(u)
+}
+
+// workspaceURLOf returns the origin of u when it is a slack.com workspace, including enterprise grid domains like acme.enterprise.slack.com
+func workspaceURLOf(u *url.URL) string {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	if !strings.HasSuffix(strings.ToLower(u.Hostname()), ".slack.com") {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
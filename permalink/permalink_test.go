@@ -0,0 +1,262 @@
+package permalink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		permalink string
+		expected  *Permalink
+	}{
+		{
+			name:      "regular message",
+			permalink: "https://workspace.slack.com/archives/C092E73H1A9/p1755857531080329",
+			expected: &Permalink{
+				WorkspaceURL: "https://workspace.slack.com",
+				ChannelID:    "C092E73H1A9",
+				Timestamp:    "1755857531.080329",
+			},
+		},
+		{
+			name:      "thread reply with thread_ts and cid",
+			permalink: "https://workspace.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=1755823401.732729&cid=C092E73H1A9",
+			expected: &Permalink{
+				WorkspaceURL: "https://workspace.slack.com",
+				ChannelID:    "C092E73H1A9",
+				Timestamp:    "1755857531.080329",
+				ThreadTs:     "1755823401.732729",
+			},
+		},
+		{
+			name:      "thread reply with message_ts",
+			permalink: "https://workspace.slack.com/archives/C092E73H1A9/p1755823401732729?thread_ts=1755823401.732729&channel=C092E73H1A9&message_ts=1755857531.080329",
+			expected: &Permalink{
+				WorkspaceURL: "https://workspace.slack.com",
+				ChannelID:    "C092E73H1A9",
+				Timestamp:    "1755857531.080329",
+				ThreadTs:     "1755823401.732729",
+			},
+		},
+		{
+			name:      "enterprise grid private channel",
+			permalink: "https://acme.enterprise.slack.com/archives/G092E73H1A9/p1755857531080329",
+			expected: &Permalink{
+				WorkspaceURL: "https://acme.enterprise.slack.com",
+				ChannelID:    "G092E73H1A9",
+				Timestamp:    "1755857531.080329",
+			},
+		},
+		{
+			name:      "direct message with trailing slash and spaces",
+			permalink: "  https://workspace.slack.com/archives/D092E73H1A9/p1755857531080329/ ",
+			expected: &Permalink{
+				WorkspaceURL: "https://workspace.slack.com",
+				ChannelID:    "D092E73H1A9",
+				Timestamp:    "1755857531.080329",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.permalink)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			permalink string
+			expectErr string
+		}{
+			{
+				"non-slack host",
+				"https://example.com/archives/C092E73H1A9/p1755857531080329",
+				"invalid permalink: host must be a slack.com workspace domain",
+			},
+			{
+				"slack.com as a subdomain of another host",
+				"https://x.slack.com.evil.io/archives/C092E73H1A9/p1755857531080329",
+				"invalid permalink: host must be a slack.com workspace domain",
+			},
+			{
+				"slack.com in the query",
+				"https://evil.io?x.slack.com/archives/C092E73H1A9/p1755857531080329",
+				"invalid permalink: host must be a slack.com workspace domain",
+			},
+			{
+				"slack.com as userinfo",
+				"https://x.slack.com@evil.io/archives/C092E73H1A9/p1755857531080329",
+				"invalid permalink: host must be a slack.com workspace domain",
+			},
+			{
+				"non-http scheme",
+				"javascript://x.slack.com/archives/C092E73H1A9/p1755857531080329",
+				"invalid permalink: host must be a slack.com workspace domain",
+			},
+			{
+				"not an archives path",
+				"https://workspace.slack.com/files/U123/F123/image.png",
+				"invalid permalink: path must be /archives/{channel_id}/p{timestamp}",
+			},
+			{
+				"channel without message",
+				"https://workspace.slack.com/archives/C092E73H1A9",
+				"invalid permalink: path must be /archives/{channel_id}/p{timestamp}",
+			},
+			{
+				"invalid thread_ts",
+				"https://workspace.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=abc",
+				"invalid permalink: thread_ts must be in format '1234567890.123456'",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Parse(tc.permalink)
+				assert.EqualError(t, err, tc.expectErr)
+			})
+		}
+	})
+}
+
+func TestPermalink_IsThreadReply(t *testing.T) {
+	assert.False(t, (&Permalink{Timestamp: "1755857531.080329"}).IsThreadReply())
+	assert.False(t, (&Permalink{Timestamp: "1755857531.080329", ThreadTs: "1755857531.080329"}).IsThreadReply())
+	assert.True(t, (&Permalink{Timestamp: "1755857531.080329", ThreadTs: "1755823401.732729"}).IsThreadReply())
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name         string
+		workspaceURL string
+		channelID    string
+		ts           string
+		threadTs     string
+		expected     string
+	}{
+		{
+			name:         "regular message",
+			workspaceURL: "https://workspace.slack.com",
+			channelID:    "C1234567",
+			ts:           "1234567890.123456",
+			expected:     "https://workspace.slack.com/archives/C1234567/p1234567890123456",
+		},
+		{
+			name:         "thread parent links without thread_ts",
+			workspaceURL: "https://workspace.slack.com",
+			channelID:    "C1234567",
+			ts:           "1234567890.123456",
+			threadTs:     "1234567890.123456",
+			expected:     "https://workspace.slack.com/archives/C1234567/p1234567890123456",
+		},
+		{
+			name:         "thread reply",
+			workspaceURL: "https://workspace.slack.com",
+			channelID:    "C1234567",
+			ts:           "1234567891.123456",
+			threadTs:     "1234567890.123456",
+			expected:     "https://workspace.slack.com/archives/C1234567/p1234567891123456?thread_ts=1234567890.123456&cid=C1234567",
+		},
+		{
+			name:      "empty without workspace URL",
+			channelID: "C1234567",
+			ts:        "1234567890.123456",
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Build(tt.workspaceURL, tt.channelID, tt.ts, tt.threadTs))
+		})
+	}
+}
+
+func TestExtractThreadTs(t *testing.T) {
+	tests := []struct {
+		name      string
+		permalink string
+		expected  string
+	}{
+		{
+			name:      "Valid permalink with thread_ts",
+			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=1755823401.732729",
+			expected:  "1755823401.732729",
+		},
+		{
+			name:      "Permalink without thread_ts",
+			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329",
+			expected:  "",
+		},
+		{
+			name:      "Empty permalink",
+			permalink: "",
+			expected:  "",
+		},
+		{
+			name:      "Permalink with thread_ts using & separator",
+			permalink: "https://workspace1.slack.com/archives/C092E73H1A9/p1755857531080329?foo=bar&thread_ts=1755823401.732729",
+			expected:  "1755823401.732729",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractThreadTs(tt.permalink)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractWorkspaceURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		permalink string
+		expected  string
+	}{
+		{
+			name:      "Valid Slack URL with query parameters",
+			permalink: "https://workspace.slack.com/archives/C092E73H1A9/p1755857531080329?thread_ts=1755823401.732729",
+			expected:  "https://workspace.slack.com",
+		},
+		{
+			name:      "Valid Slack URL with subdomain",
+			permalink: "https://my-company.slack.com/archives/C092E73H1A9/p1755857531080329",
+			expected:  "https://my-company.slack.com",
+		},
+		{
+			name:      "Enterprise grid URL",
+			permalink: "https://acme.enterprise.slack.com/archives/C092E73H1A9/p1755857531080329",
+			expected:  "https://acme.enterprise.slack.com",
+		},
+		{
+			name:      "slack.com as a subdomain of another host",
+			permalink: "https://x.slack.com.evil.io/archives/C092E73H1A9/p1755857531080329",
+			expected:  "",
+		},
+		{
+			name:      "slack.com in the query",
+			permalink: "https://evil.io?x.slack.com/archives/C092E73H1A9/p1755857531080329",
+			expected:  "",
+		},
+		{
+			name:      "Empty permalink",
+			permalink: "",
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractWorkspaceURL(tt.permalink)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	cacheTTL           = 30 * time.Minute
+	cacheSweepInterval = 5 * time.Minute
+)
+
+// sessionCacheEntry is a value cached for one session
+type sessionCacheEntry[T any] struct {
+	value    T
+	cachedAt time.Time
+}
+
+// sessionCache holds one value per session for cacheTTL.
+// A background sweeper removes expired entries so that sessions that never come back do not leak memory.
+type sessionCache[T any] struct {
+	entries   map[SessionID]*sessionCacheEntry[T]
+	mu        sync.RWMutex
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+
+	// for test
+	now func() time.Time
+}
+
+// newSessionCache creates a sessionCache and starts its sweeper. Call close to stop it.
+func newSessionCache[T any]() *sessionCache[T] {
+	c := &sessionCache[T]{
+		entries: make(map[SessionID]*sessionCacheEntry[T]),
+		stopCh:  make(chan struct{}),
+
+		now: time.Now,
+	}
+
+	c.wg.Add(1)
+	go c.sweeper()
+
+	return c
+}
+
+// get returns the value cached for the session, if any and not expired
+func (c *sessionCache[T]) get(sessionID SessionID) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, exists := c.entries[sessionID]
+	if !exists || c.isExpired(entry) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set caches the value for the session, restarting its TTL
+func (c *sessionCache[T]) set(sessionID SessionID, value T) {
+	c.mu.Lock()
+	c.entries[sessionID] = &sessionCacheEntry[T]{
+		value:    value,
+		cachedAt: c.now(),
+	}
+	c.mu.Unlock()
+}
+
+// update modifies the cached value in place without restarting its TTL. It does nothing when the session has no valid entry.
+func (c *sessionCache[T]) update(sessionID SessionID, fn func(value *T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, exists := c.entries[sessionID]; exists && !c.isExpired(entry) {
+		fn(&entry.value)
+	}
+}
+
+// getOrLoad returns the value cached for the request's session, calling load and caching its result on a miss.
+// kind names the cached data in debug logs (e.g., "users").
+func (c *sessionCache[T]) getOrLoad(ctx context.Context, kind string, load func() (T, error)) (T, error) {
+	sessionID := SessionIDFromContext(ctx)
+
+	if value, ok := c.get(sessionID); ok {
+		slog.Debug("using cached "+kind, "sessionID", sessionID)
+		return value, nil
+	}
+
+	slog.Debug("fetching "+kind, "sessionID", sessionID)
+
+	value, err := load()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.set(sessionID, value)
+
+	return value, nil
+}
+
+func (c *sessionCache[T]) isExpired(entry *sessionCacheEntry[T]) bool {
+	return c.now().Sub(entry.cachedAt) > cacheTTL
+}
+
+func (c *sessionCache[T]) sweeper() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(cacheSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.sweepExpired()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *sessionCache[T]) sweepExpired() {
+	c.mu.Lock()
+	for sessionID, entry := range c.entries {
+		if c.isExpired(entry) {
+			delete(c.entries, sessionID)
+		}
+	}
+	c.mu.Unlock()
+}
+
+// close stops the sweeper. It is safe to call more than once.
+func (c *sessionCache[T]) close() {
+	c.closeOnce.Do(func() {
+		close(c.stopCh)
+	})
+	c.wg.Wait()
+}
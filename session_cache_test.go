@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCache(t *testing.T) {
+	t.Run("keeps values until the TTL has passed", func(t *testing.T) {
+		now := time.Now()
+		cache := newSessionCache[string]()
+		cache.now = func() time.Time { return now }
+		t.Cleanup(cache.close)
+
+		cache.set("session-1", "value")
+
+		now = now.Add(cacheTTL)
+		value, ok := cache.get("session-1")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+
+		now = now.Add(time.Second)
+		_, ok = cache.get("session-1")
+		assert.False(t, ok)
+	})
+
+	t.Run("isolates sessions", func(t *testing.T) {
+		cache := newSessionCache[string]()
+		t.Cleanup(cache.close)
+
+		cache.set("session-1", "one")
+		cache.set("session-2", "two")
+
+		value, ok := cache.get("session-1")
+		assert.True(t, ok)
+		assert.Equal(t, "one", value)
+		value, ok = cache.get("session-2")
+		assert.True(t, ok)
+		assert.Equal(t, "two", value)
+		_, ok = cache.get("session-3")
+		assert.False(t, ok)
+	})
+
+	t.Run("getOrLoad loads once per session and does not cache errors", func(t *testing.T) {
+		cache := newSessionCache[int]()
+		t.Cleanup(cache.close)
+
+		calls := 0
+		load := func() (int, error) {
+			calls++
+			return calls, nil
+		}
+		ctx1 := WithSessionID(t.Context(), "session-1")
+		ctx2 := WithSessionID(t.Context(), "session-2")
+
+		_, err := cache.getOrLoad(ctx1, "numbers", func() (int, error) { return 0, errors.New("boom") })
+		assert.EqualError(t, err, "boom")
+
+		value, err := cache.getOrLoad(ctx1, "numbers", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+		value, err = cache.getOrLoad(ctx1, "numbers", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+		value, err = cache.getOrLoad(ctx2, "numbers", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, value)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("update changes only valid entries without restarting the TTL", func(t *testing.T) {
+		now := time.Now()
+		cache := newSessionCache[map[string]int]()
+		cache.now = func() time.Time { return now }
+		t.Cleanup(cache.close)
+
+		cache.set("session-1", map[string]int{})
+		now = now.Add(cacheTTL)
+		cache.update("session-1", func(value *map[string]int) { (*value)["a"] = 1 })
+		value, ok := cache.get("session-1")
+		assert.True(t, ok)
+		assert.Equal(t, map[string]int{"a": 1}, value)
+
+		now = now.Add(time.Second)
+		called := false
+		cache.update("session-1", func(value *map[string]int) { called = true })
+		assert.False(t, called)
+	})
+
+	t.Run("sweepExpired removes only expired entries", func(t *testing.T) {
+		now := time.Now()
+		cache := newSessionCache[string]()
+		cache.now = func() time.Time { return now }
+		t.Cleanup(cache.close)
+
+		cache.set("session-old", "old")
+		now = now.Add(cacheTTL)
+		cache.set("session-new", "new")
+		now = now.Add(time.Second)
+
+		cache.sweepExpired()
+
+		cache.mu.RLock()
+		defer cache.mu.RUnlock()
+		assert.Len(t, cache.entries, 1)
+		assert.Contains(t, cache.entries, SessionID("session-new"))
+	})
+
+	t.Run("close can be called more than once", func(t *testing.T) {
+		cache := newSessionCache[string]()
+		cache.close()
+		cache.close()
+	})
+}
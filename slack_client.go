@@ -2,21 +2,50 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/slack-go/slack"
 )
 
+// ErrMissingScope is returned when the token lacks an OAuth scope required by the API method
+var ErrMissingScope = errors.New("missing required scope")
+
+// ErrUserNotFound is returned when no user matches the given ID or email
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrChannelNotFound is returned when the conversation does not exist or is not visible to the user
+var ErrChannelNotFound = errors.New("channel not found")
+
+// RateLimitedError is returned when Slack rejects a request with HTTP 429
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited: retry after %d seconds", int(e.RetryAfter.Seconds()))
+}
+
 // SlackClient is an interface for Slack API operations
 type SlackClient interface {
-	SearchMessages(query string, params slack.SearchParameters) (*slack.SearchMessages, error)
-	SearchFiles(query string, params slack.SearchParameters) (*slack.SearchFiles, error)
-	GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error)
-	GetUserProfile(userID string) (*slack.UserProfile, error)
+	SearchMessages(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, error)
+	SearchFiles(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+	GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error)
+	GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	GetUserProfile(ctx context.Context, userID string) (*slack.UserProfile, error)
 	GetUsers(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
-	GetFileInfo(fileID string) (*slack.File, error)
-	GetFile(downloadURL string, writer io.Writer) error
+	GetUserByEmail(ctx context.Context, email string) (*slack.User, error)
+	GetUserInfo(ctx context.Context, userID string) (*slack.User, error)
+	GetTeamProfile(ctx context.Context) (*slack.TeamProfile, error)
+	GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationInfo(ctx context.Context, channelID string) (*slack.Channel, error)
+	GetFileInfo(ctx context.Context, fileID string) (*slack.File, error)
+	GetUserGroups(ctx context.Context) ([]slack.UserGroup, error)
+	GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error)
+	AuthTest(ctx context.Context) (*slack.AuthTestResponse, error)
+	GetFile(ctx context.Context, downloadURL string, writer io.Writer) error
 }
 
 type slackClient struct {
@@ -31,8 +60,8 @@ func NewSlackClient(userToken string) SlackClient {
 }
 
 // SearchMessages searches for messages in Slack workspace
-func (c *slackClient) SearchMessages(query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
-	messages, err := c.client.SearchMessages(query, params)
+func (c *slackClient) SearchMessages(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
+	messages, err := c.client.SearchMessagesContext(ctx, query, params)
 	if err != nil {
 		return nil, c.mapError(err)
 	}
@@ -40,8 +69,8 @@ func (c *slackClient) SearchMessages(query string, params slack.SearchParameters
 }
 
 // SearchFiles searches for files in Slack workspace
-func (c *slackClient) SearchFiles(query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
-	files, err := c.client.SearchFiles(query, params)
+func (c *slackClient) SearchFiles(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	files, err := c.client.SearchFilesContext(ctx, query, params)
 	if err != nil {
 		return nil, c.mapError(err)
 	}
@@ -49,17 +78,26 @@ func (c *slackClient) SearchFiles(query string, params slack.SearchParameters) (
 }
 
 // GetConversationReplies retrieves replies to a message thread
-func (c *slackClient) GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
-	messages, hasMore, nextCursor, err := c.client.GetConversationReplies(params)
+func (c *slackClient) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	messages, hasMore, nextCursor, err := c.client.GetConversationRepliesContext(ctx, params)
 	if err != nil {
 		return nil, false, "", c.mapError(err)
 	}
 	return messages, hasMore, nextCursor, nil
 }
 
+// GetConversationHistory retrieves messages posted to a conversation
+func (c *slackClient) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	history, err := c.client.GetConversationHistoryContext(ctx, params)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return history, nil
+}
+
 // GetUserProfile retrieves a user's profile information
-func (c *slackClient) GetUserProfile(userID string) (*slack.UserProfile, error) {
-	profile, err := c.client.GetUserProfile(&slack.GetUserProfileParameters{
+func (c *slackClient) GetUserProfile(ctx context.Context, userID string) (*slack.UserProfile, error) {
+	profile, err := c.client.GetUserProfileContext(ctx, &slack.GetUserProfileParameters{
 		UserID: userID,
 	})
 	if err != nil {
@@ -78,27 +116,104 @@ func (c *slackClient) GetUsers(ctx context.Context, options ...slack.GetUsersOpt
 	return users, nil
 }
 
+// GetUserByEmail retrieves the user with the given email address
+func (c *slackClient) GetUserByEmail(ctx context.Context, email string) (*slack.User, error) {
+	user, err := c.client.GetUserByEmailContext(ctx, email)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return user, nil
+}
+
+// GetUserInfo retrieves a user's account information, such as timezone and admin or guest flags
+func (c *slackClient) GetUserInfo(ctx context.Context, userID string) (*slack.User, error) {
+	user, err := c.client.GetUserInfoContext(ctx, userID)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return user, nil
+}
+
+// GetTeamProfile retrieves the workspace's custom profile field definitions
+func (c *slackClient) GetTeamProfile(ctx context.Context) (*slack.TeamProfile, error) {
+	profile, err := c.client.GetTeamProfileContext(ctx)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return profile, nil
+}
+
+// GetConversations retrieves a page of conversations from the workspace
+func (c *slackClient) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	channels, nextCursor, err := c.client.GetConversationsContext(ctx, params)
+	if err != nil {
+		return nil, "", c.mapError(err)
+	}
+	return channels, nextCursor, nil
+}
+
+// GetConversationInfo retrieves a single conversation by ID
+func (c *slackClient) GetConversationInfo(ctx context.Context, channelID string) (*slack.Channel, error) {
+	channel, err := c.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: channelID})
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return channel, nil
+}
+
 // GetFileInfo retrieves file information by file ID
-func (c *slackClient) GetFileInfo(fileID string) (*slack.File, error) {
-	file, _, _, err := c.client.GetFileInfo(fileID, 0, 0)
+func (c *slackClient) GetFileInfo(ctx context.Context, fileID string) (*slack.File, error) {
+	file, _, _, err := c.client.GetFileInfoContext(ctx, fileID, 0, 0)
 	if err != nil {
 		return nil, c.mapError(err)
 	}
 	return file, nil
 }
 
+// GetUserGroups retrieves all user groups in the workspace, including disabled ones
+func (c *slackClient) GetUserGroups(ctx context.Context) ([]slack.UserGroup, error) {
+	groups, err := c.client.GetUserGroupsContext(ctx,
+		slack.GetUserGroupsOptionIncludeDisabled(true),
+		slack.GetUserGroupsOptionIncludeCount(true),
+	)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return groups, nil
+}
+
+// GetUserGroupMembers retrieves the user IDs of a user group's members
+func (c *slackClient) GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	members, err := c.client.GetUserGroupMembersContext(ctx, userGroupID,
+		slack.GetUserGroupMembersOptionIncludeDisabled(true),
+	)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return members, nil
+}
+
 // GetFile downloads a file from a private download URL
-func (c *slackClient) GetFile(downloadURL string, writer io.Writer) error {
-	err := c.client.GetFile(downloadURL, writer)
+func (c *slackClient) GetFile(ctx context.Context, downloadURL string, writer io.Writer) error {
+	err := c.client.GetFileContext(ctx, downloadURL, writer)
 	if err != nil {
 		return c.mapError(err)
 	}
 	return nil
 }
 
+// AuthTest retrieves the identity of the token, including the workspace URL
+func (c *slackClient) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	response, err := c.client.AuthTestContext(ctx)
+	if err != nil {
+		return nil, c.mapError(err)
+	}
+	return response, nil
+}
+
 func (c *slackClient) mapError(err error) error {
 	if rateLimitErr, ok := err.(*slack.RateLimitedError); ok {
-		return fmt.Errorf("rate limited: retry after %d seconds", rateLimitErr.RetryAfter)
+		return &RateLimitedError{RetryAfter: rateLimitErr.RetryAfter}
 	}
 
 	if slackErr, ok := err.(slack.SlackErrorResponse); ok {
@@ -106,15 +221,17 @@ func (c *slackClient) mapError(err error) error {
 		case "not_authed", "invalid_auth":
 			return fmt.Errorf("authentication failed: %s", slackErr.Err)
 		case "missing_scope":
-			return fmt.Errorf("missing required scope: %s", slackErr.Err)
+			return fmt.Errorf("%w: %s", ErrMissingScope, slackErr.Err)
 		case "channel_not_found":
-			return fmt.Errorf("channel not found: %s", slackErr.Err)
-		case "user_not_found":
-			return fmt.Errorf("user not found: %s", slackErr.Err)
+			return fmt.Errorf("%w: %s", ErrChannelNotFound, slackErr.Err)
+		case "user_not_found", "users_not_found":
+			return fmt.Errorf("%w: %s", ErrUserNotFound, slackErr.Err)
 		case "thread_not_found":
 			return fmt.Errorf("thread not found: %s", slackErr.Err)
 		case "file_not_found":
 			return fmt.Errorf("file not found: %s", slackErr.Err)
+		case "no_such_subteam":
+			return fmt.Errorf("user group not found: %s", slackErr.Err)
 		default:
 			return fmt.Errorf("slack API error: %s", slackErr.Err)
 		}
@@ -10,8 +10,8 @@ import (
 
 type SlackClientMock struct{ mock.Mock }
 
-func (m *SlackClientMock) SearchMessages(query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
-	args := m.Called(query, params)
+func (m *SlackClientMock) SearchMessages(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
+	args := m.Called(ctx, query, params)
 	var res *slack.SearchMessages
 	if v := args.Get(0); v != nil {
 		res = v.(*slack.SearchMessages)
@@ -19,8 +19,8 @@ func (m *SlackClientMock) SearchMessages(query string, params slack.SearchParame
 	return res, args.Error(1)
 }
 
-func (m *SlackClientMock) SearchFiles(query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
-	args := m.Called(query, params)
+func (m *SlackClientMock) SearchFiles(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	args := m.Called(ctx, query, params)
 	var res *slack.SearchFiles
 	if v := args.Get(0); v != nil {
 		res = v.(*slack.SearchFiles)
@@ -28,14 +28,23 @@ func (m *SlackClientMock) SearchFiles(query string, params slack.SearchParameter
 	return res, args.Error(1)
 }
 
-func (m *SlackClientMock) GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
-	args := m.Called(params)
+func (m *SlackClientMock) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	args := m.Called(ctx, params)
 	msgs, _ := args.Get(0).([]slack.Message)
 	return msgs, args.Bool(1), args.String(2), args.Error(3)
 }
 
-func (m *SlackClientMock) GetUserProfile(userID string) (*slack.UserProfile, error) {
-	args := m.Called(userID)
+func (m *SlackClientMock) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	args := m.Called(ctx, params)
+	var res *slack.GetConversationHistoryResponse
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.GetConversationHistoryResponse)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetUserProfile(ctx context.Context, userID string) (*slack.UserProfile, error) {
+	args := m.Called(ctx, userID)
 	var res *slack.UserProfile
 	if v := args.Get(0); v != nil {
 		res = v.(*slack.UserProfile)
@@ -52,8 +61,53 @@ func (m *SlackClientMock) GetUsers(ctx context.Context, options ...slack.GetUser
 	return res, args.Error(1)
 }
 
-func (m *SlackClientMock) GetFileInfo(fileID string) (*slack.File, error) {
-	args := m.Called(fileID)
+func (m *SlackClientMock) GetConversationInfo(ctx context.Context, channelID string) (*slack.Channel, error) {
+	args := m.Called(ctx, channelID)
+	var res *slack.Channel
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.Channel)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetUserInfo(ctx context.Context, userID string) (*slack.User, error) {
+	args := m.Called(ctx, userID)
+	var res *slack.User
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.User)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetTeamProfile(ctx context.Context) (*slack.TeamProfile, error) {
+	args := m.Called(ctx)
+	var res *slack.TeamProfile
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.TeamProfile)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetUserByEmail(ctx context.Context, email string) (*slack.User, error) {
+	args := m.Called(ctx, email)
+	var res *slack.User
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.User)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	args := m.Called(ctx, params)
+	var res []slack.Channel
+	if v := args.Get(0); v != nil {
+		res = v.([]slack.Channel)
+	}
+	return res, args.String(1), args.Error(2)
+}
+
+func (m *SlackClientMock) GetFileInfo(ctx context.Context, fileID string) (*slack.File, error) {
+	args := m.Called(ctx, fileID)
 	var res *slack.File
 	if v := args.Get(0); v != nil {
 		res = v.(*slack.File)
@@ -61,7 +115,34 @@ func (m *SlackClientMock) GetFileInfo(fileID string) (*slack.File, error) {
 	return res, args.Error(1)
 }
 
-func (m *SlackClientMock) GetFile(downloadURL string, writer io.Writer) error {
-	args := m.Called(downloadURL, writer)
+func (m *SlackClientMock) GetUserGroups(ctx context.Context) ([]slack.UserGroup, error) {
+	args := m.Called(ctx)
+	var res []slack.UserGroup
+	if v := args.Get(0); v != nil {
+		res = v.([]slack.UserGroup)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	args := m.Called(ctx, userGroupID)
+	var res []string
+	if v := args.Get(0); v != nil {
+		res = v.([]string)
+	}
+	return res, args.Error(1)
+}
+
+func (m *SlackClientMock) GetFile(ctx context.Context, downloadURL string, writer io.Writer) error {
+	args := m.Called(ctx, downloadURL, writer)
 	return args.Error(0)
 }
+
+func (m *SlackClientMock) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	args := m.Called(ctx)
+	var res *slack.AuthTestResponse
+	if v := args.Get(0); v != nil {
+		res = v.(*slack.AuthTestResponse)
+	}
+	return res, args.Error(1)
+}
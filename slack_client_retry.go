@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// RetryConfig controls how failed Slack API calls are retried
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. 0 disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry of a transient error. It doubles on each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff for transient errors
+	MaxDelay time.Duration
+	// MaxRetryAfter is the longest Retry-After the client will wait out. Longer rate limits are returned as errors.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryConfig returns the retry settings used when no environment variables are set
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:    3,
+		BaseDelay:     time.Second,
+		MaxDelay:      30 * time.Second,
+		MaxRetryAfter: time.Minute,
+	}
+}
+
+// LoadRetryConfigFromEnv reads retry settings from environment variables, falling back to defaults for unset or invalid values.
+//   - SLACK_RETRY_MAX_RETRIES: number of retries (e.g., "3", "0" disables retries)
+//   - SLACK_RETRY_BASE_DELAY: initial backoff (e.g., "1s")
+//   - SLACK_RETRY_MAX_DELAY: backoff cap (e.g., "30s")
+//   - SLACK_RETRY_MAX_RETRY_AFTER: longest Retry-After to wait out (e.g., "1m")
+func LoadRetryConfigFromEnv() RetryConfig {
+	config := DefaultRetryConfig()
+
+	if v := os.Getenv("SLACK_RETRY_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			config.MaxRetries = n
+		} else {
+			slog.Warn("ignoring invalid SLACK_RETRY_MAX_RETRIES", "value", v)
+		}
+	}
+	loadDuration := func(name string, target *time.Duration) {
+		v := os.Getenv(name)
+		if v == "" {
+			return
+		}
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			*target = d
+		} else {
+			slog.Warn("ignoring invalid "+name, "value", v)
+		}
+	}
+	loadDuration("SLACK_RETRY_BASE_DELAY", &config.BaseDelay)
+	loadDuration("SLACK_RETRY_MAX_DELAY", &config.MaxDelay)
+	loadDuration("SLACK_RETRY_MAX_RETRY_AFTER", &config.MaxRetryAfter)
+
+	return config
+}
+
+// retryClock abstracts time so tests can run retries without sleeping
+type retryClock interface {
+	Now() time.Time
+	// Sleep waits for d or until ctx is done, returning ctx.Err() in the latter case
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryingSlackClient wraps a SlackClient and retries rate-limited and transient failures
+type retryingSlackClient struct {
+	next   SlackClient
+	config RetryConfig
+	clock  retryClock
+	// jitter returns a random value in [0, 1) used to spread out backoff
+	jitter func() float64
+}
+
+// NewRetryingSlackClient wraps next so that rate limits are waited out and transient errors are retried with backoff
+func NewRetryingSlackClient(next SlackClient, config RetryConfig) SlackClient {
+	return &retryingSlackClient{
+		next:   next,
+		config: config,
+		clock:  realClock{},
+		jitter: rand.Float64,
+	}
+}
+
+// do runs fn until it succeeds, fails with a non-retryable error, runs out of retries, or ctx is done
+func (c *retryingSlackClient) do(ctx context.Context, method string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= c.config.MaxRetries {
+			return err
+		}
+
+		wait, ok := c.retryDelay(err, attempt)
+		if !ok {
+			return err
+		}
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && c.clock.Now().Add(wait).After(deadline) {
+			// Waiting would outlive the caller, so report the original failure right away
+			return err
+		}
+
+		slog.Debug("retrying Slack API call", "method", method, "attempt", attempt+1, "wait", wait, "error", err)
+		if sleepErr := c.clock.Sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying err, or false when err should not be retried
+func (c *retryingSlackClient) retryDelay(err error, attempt int) (time.Duration, bool) {
+	var rateLimitErr *RateLimitedError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > c.config.MaxRetryAfter {
+			return 0, false
+		}
+		if rateLimitErr.RetryAfter > 0 {
+			return rateLimitErr.RetryAfter, true
+		}
+		return c.backoff(attempt), true
+	}
+
+	if isTransientError(err) {
+		return c.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// backoff returns an exponential delay capped at MaxDelay, jittered into [delay/2, delay)
+func (c *retryingSlackClient) backoff(attempt int) time.Duration {
+	delay := c.config.BaseDelay << attempt
+	if delay > c.config.MaxDelay || delay <= 0 {
+		delay = c.config.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(c.jitter()*float64(half))
+}
+
+// isTransientError reports whether err is a 5xx response or a network failure worth retrying
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (c *retryingSlackClient) SearchMessages(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
+	var result *slack.SearchMessages
+	err := c.do(ctx, "search.messages", func() (err error) {
+		result, err = c.next.SearchMessages(ctx, query, params)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) SearchFiles(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	var result *slack.SearchFiles
+	err := c.do(ctx, "search.files", func() (err error) {
+		result, err = c.next.SearchFiles(ctx, query, params)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	var (
+		messages   []slack.Message
+		hasMore    bool
+		nextCursor string
+	)
+	err := c.do(ctx, "conversations.replies", func() (err error) {
+		messages, hasMore, nextCursor, err = c.next.GetConversationReplies(ctx, params)
+		return err
+	})
+	return messages, hasMore, nextCursor, err
+}
+
+func (c *retryingSlackClient) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	var result *slack.GetConversationHistoryResponse
+	err := c.do(ctx, "conversations.history", func() (err error) {
+		result, err = c.next.GetConversationHistory(ctx, params)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUserProfile(ctx context.Context, userID string) (*slack.UserProfile, error) {
+	var result *slack.UserProfile
+	err := c.do(ctx, "users.profile.get", func() (err error) {
+		result, err = c.next.GetUserProfile(ctx, userID)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUsers(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error) {
+	var result []slack.User
+	err := c.do(ctx, "users.list", func() (err error) {
+		result, err = c.next.GetUsers(ctx, options...)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	var (
+		channels   []slack.Channel
+		nextCursor string
+	)
+	err := c.do(ctx, "conversations.list", func() (err error) {
+		channels, nextCursor, err = c.next.GetConversations(ctx, params)
+		return err
+	})
+	return channels, nextCursor, err
+}
+
+func (c *retryingSlackClient) GetConversationInfo(ctx context.Context, channelID string) (*slack.Channel, error) {
+	var result *slack.Channel
+	err := c.do(ctx, "conversations.info", func() (err error) {
+		result, err = c.next.GetConversationInfo(ctx, channelID)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetFileInfo(ctx context.Context, fileID string) (*slack.File, error) {
+	var result *slack.File
+	err := c.do(ctx, "files.info", func() (err error) {
+		result, err = c.next.GetFileInfo(ctx, fileID)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUserByEmail(ctx context.Context, email string) (*slack.User, error) {
+	var result *slack.User
+	err := c.do(ctx, "users.lookupByEmail", func() (err error) {
+		result, err = c.next.GetUserByEmail(ctx, email)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUserInfo(ctx context.Context, userID string) (*slack.User, error) {
+	var result *slack.User
+	err := c.do(ctx, "users.info", func() (err error) {
+		result, err = c.next.GetUserInfo(ctx, userID)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetTeamProfile(ctx context.Context) (*slack.TeamProfile, error) {
+	var result *slack.TeamProfile
+	err := c.do(ctx, "team.profile.get", func() (err error) {
+		result, err = c.next.GetTeamProfile(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUserGroups(ctx context.Context) ([]slack.UserGroup, error) {
+	var result []slack.UserGroup
+	err := c.do(ctx, "usergroups.list", func() (err error) {
+		result, err = c.next.GetUserGroups(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) GetUserGroupMembers(ctx context.Context, userGroupID string) ([]string, error) {
+	var result []string
+	err := c.do(ctx, "usergroups.users.list", func() (err error) {
+		result, err = c.next.GetUserGroupMembers(ctx, userGroupID)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingSlackClient) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	var result *slack.AuthTestResponse
+	err := c.do(ctx, "auth.test", func() (err error) {
+		result, err = c.next.AuthTest(ctx)
+		return err
+	})
+	return result, err
+}
+
+// GetFile retries a download only while nothing has been written, so a partial download is never duplicated
+func (c *retryingSlackClient) GetFile(ctx context.Context, downloadURL string, writer io.Writer) error {
+	counter := &countingWriter{w: writer}
+	var lastErr error
+	err := c.do(ctx, "files.download", func() error {
+		lastErr = c.next.GetFile(ctx, downloadURL, counter)
+		if lastErr != nil && counter.n > 0 {
+			return nil
+		}
+		return lastErr
+	})
+	if err != nil {
+		return err
+	}
+	return lastErr
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
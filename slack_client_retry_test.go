@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeClock records requested sleeps and advances time instantly
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func newTestRetryingClient(next SlackClient, config RetryConfig) (*retryingSlackClient, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return &retryingSlackClient{
+		next:   next,
+		config: config,
+		clock:  clock,
+		jitter: func() float64 { return 0.5 },
+	}, clock
+}
+
+func TestRetryingSlackClient(t *testing.T) {
+	config := RetryConfig{
+		MaxRetries:    3,
+		BaseDelay:     time.Second,
+		MaxDelay:      3 * time.Second,
+		MaxRetryAfter: time.Minute,
+	}
+
+	t.Run("waits out Retry-After and succeeds", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(nil, &RateLimitedError{RetryAfter: 20 * time.Second}).Once()
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(&slack.UserProfile{DisplayName: "john"}, nil).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		profile, err := client.GetUserProfile(t.Context(), "U1234567")
+		assert.NoError(t, err)
+		assert.Equal(t, "john", profile.DisplayName)
+		assert.Equal(t, []time.Duration{20 * time.Second}, clock.sleeps)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("backs off exponentially with jitter for transient errors", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(nil, slack.StatusCodeError{Code: 503, Status: "503 Service Unavailable"}).Times(3)
+		mockClient.On("GetFileInfo", mock.Anything, "F1234567").Return(&slack.File{ID: "F1234567"}, nil).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		file, err := client.GetFileInfo(t.Context(), "F1234567")
+		assert.NoError(t, err)
+		assert.Equal(t, "F1234567", file.ID)
+		// 1s, 2s, then capped at 3s, each jittered to 75% with jitter 0.5
+		assert.Equal(t, []time.Duration{750 * time.Millisecond, 1500 * time.Millisecond, 2250 * time.Millisecond}, clock.sleeps)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("retries network errors", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		mockClient.On("AuthTest", mock.Anything).Return(nil, netErr).Once()
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		res, err := client.AuthTest(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "https://workspace.slack.com/", res.URL)
+		assert.Len(t, clock.sleeps, 1)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		rateLimitErr := &RateLimitedError{RetryAfter: time.Second}
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(nil, rateLimitErr).Times(4)
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		_, err := client.GetUserProfile(t.Context(), "U1234567")
+		assert.Equal(t, rateLimitErr, err)
+		assert.Equal(t, "rate limited: retry after 1 seconds", err.Error())
+		assert.Len(t, clock.sleeps, 3)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			err  error
+		}{
+			{"missing scope", ErrMissingScope},
+			{"client error status", slack.StatusCodeError{Code: 404, Status: "404 Not Found"}},
+			{"canceled context", context.Canceled},
+			{"Retry-After longer than MaxRetryAfter", &RateLimitedError{RetryAfter: 2 * time.Minute}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(nil, tc.err).Once()
+
+				client, clock := newTestRetryingClient(mockClient, config)
+
+				_, err := client.GetUserProfile(t.Context(), "U1234567")
+				assert.Equal(t, tc.err, err)
+				assert.Empty(t, clock.sleeps)
+				mockClient.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("does not wait beyond the context deadline", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		rateLimitErr := &RateLimitedError{RetryAfter: 30 * time.Second}
+		mockClient.On("GetUsers", mock.Anything, mock.Anything).Return(nil, rateLimitErr).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+		ctx, cancel := context.WithDeadline(t.Context(), clock.now.Add(10*time.Second))
+		defer cancel()
+
+		_, err := client.GetUsers(ctx)
+		assert.Equal(t, rateLimitErr, err)
+		assert.Empty(t, clock.sleeps)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("stops when the context is canceled while waiting", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		rateLimitErr := &RateLimitedError{RetryAfter: time.Second}
+		mockClient.On("GetConversations", mock.Anything, mock.Anything).Return(nil, "", rateLimitErr).Once()
+
+		client, _ := newTestRetryingClient(mockClient, config)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, _, err := client.GetConversations(ctx, &slack.GetConversationsParameters{})
+		assert.Equal(t, rateLimitErr, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("disabled when MaxRetries is 0", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserProfile", mock.Anything, "U1234567").Return(nil, &RateLimitedError{RetryAfter: time.Second}).Once()
+
+		client, clock := newTestRetryingClient(mockClient, RetryConfig{})
+
+		_, err := client.GetUserProfile(t.Context(), "U1234567")
+		assert.Error(t, err)
+		assert.Empty(t, clock.sleeps)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("does not retry a download that already wrote data", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/file", mock.Anything).Run(func(args mock.Arguments) {
+			_, _ = args.Get(2).(io.Writer).Write([]byte("partial"))
+		}).Return(slack.StatusCodeError{Code: 502, Status: "502 Bad Gateway"}).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		var buf bytes.Buffer
+		err := client.GetFile(t.Context(), "https://files.slack.com/file", &buf)
+		assert.Equal(t, slack.StatusCodeError{Code: 502, Status: "502 Bad Gateway"}, err)
+		assert.Equal(t, "partial", buf.String())
+		assert.Empty(t, clock.sleeps)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("retries a download that failed before writing", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/file", mock.Anything).Return(slack.StatusCodeError{Code: 502, Status: "502 Bad Gateway"}).Once()
+		mockClient.On("GetFile", mock.Anything, "https://files.slack.com/file", mock.Anything).Run(func(args mock.Arguments) {
+			_, _ = args.Get(2).(io.Writer).Write([]byte("content"))
+		}).Return(nil).Once()
+
+		client, clock := newTestRetryingClient(mockClient, config)
+
+		var buf bytes.Buffer
+		err := client.GetFile(t.Context(), "https://files.slack.com/file", &buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "content", buf.String())
+		assert.Len(t, clock.sleeps, 1)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestLoadRetryConfigFromEnv(t *testing.T) {
+	t.Run("uses defaults when unset", func(t *testing.T) {
+		assert.Equal(t, DefaultRetryConfig(), LoadRetryConfigFromEnv())
+	})
+
+	t.Run("reads values from environment", func(t *testing.T) {
+		t.Setenv("SLACK_RETRY_MAX_RETRIES", "5")
+		t.Setenv("SLACK_RETRY_BASE_DELAY", "500ms")
+		t.Setenv("SLACK_RETRY_MAX_DELAY", "10s")
+		t.Setenv("SLACK_RETRY_MAX_RETRY_AFTER", "2m")
+
+		assert.Equal(t, RetryConfig{
+			MaxRetries:    5,
+			BaseDelay:     500 * time.Millisecond,
+			MaxDelay:      10 * time.Second,
+			MaxRetryAfter: 2 * time.Minute,
+		}, LoadRetryConfigFromEnv())
+	})
+
+	t.Run("ignores invalid values", func(t *testing.T) {
+		t.Setenv("SLACK_RETRY_MAX_RETRIES", "-1")
+		t.Setenv("SLACK_RETRY_BASE_DELAY", "soon")
+
+		assert.Equal(t, DefaultRetryConfig(), LoadRetryConfigFromEnv())
+	})
+}
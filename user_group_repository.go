@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// userGroupCacheValue holds the user groups and the members fetched so far for a session.
+// members is replaced rather than modified so that callers can read a map obtained from the cache without locking.
+type userGroupCacheValue struct {
+	groups []slack.UserGroup
+	// members maps a user group ID to its member IDs
+	members map[string][]string
+}
+
+// UserGroupFilter specifies conditions for FindUserGroups
+type UserGroupFilter struct {
+	// Query matches user groups whose handle or name contains this substring (case-insensitive)
+	Query           string
+	IncludeDisabled bool
+}
+
+// UserGroupRepository manages user group information with session-based caching
+type UserGroupRepository struct {
+	cache *sessionCache[userGroupCacheValue]
+}
+
+// NewUserGroupRepository creates a new UserGroupRepository
+func NewUserGroupRepository() *UserGroupRepository {
+	return &UserGroupRepository{
+		cache: newSessionCache[userGroupCacheValue](),
+	}
+}
+
+// FindUserGroups returns the user groups matching the filter
+func (r *UserGroupRepository) FindUserGroups(
+	ctx context.Context,
+	client SlackClient,
+	filter UserGroupFilter,
+) ([]slack.UserGroup, error) {
+	groups, err := r.getUserGroups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return r.filterUserGroups(groups, filter), nil
+}
+
+// FindByRef returns the user group with the given ID or handle, or nil if none matches.
+// Handles may be prefixed with "@" and are matched case-insensitively.
+func (r *UserGroupRepository) FindByRef(ctx context.Context, client SlackClient, ref string) (*slack.UserGroup, error) {
+	groups, err := r.getUserGroups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := strings.TrimPrefix(ref, "@")
+	for i := range groups {
+		if groups[i].ID == ref || strings.EqualFold(groups[i].Handle, handle) {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetMembers returns the member IDs of a user group, caching them for the session
+func (r *UserGroupRepository) GetMembers(ctx context.Context, client SlackClient, userGroupID string) ([]string, error) {
+	sessionID := SessionIDFromContext(ctx)
+
+	if value, ok := r.cache.get(sessionID); ok {
+		if members, cached := value.members[userGroupID]; cached {
+			slog.Debug("using cached user group members", "sessionID", sessionID, "userGroupID", userGroupID)
+			return members, nil
+		}
+	}
+
+	slog.Debug("fetching user group members", "sessionID", sessionID, "userGroupID", userGroupID)
+
+	members, err := client.GetUserGroupMembers(ctx, userGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members are cached alongside the groups so that both expire together
+	r.cache.update(sessionID, func(value *userGroupCacheValue) {
+		updated := maps.Clone(value.members)
+		updated[userGroupID] = members
+		value.members = updated
+	})
+
+	return members, nil
+}
+
+// getUserGroups returns the cached user groups for the session, loading them from Slack on a miss
+func (r *UserGroupRepository) getUserGroups(ctx context.Context, client SlackClient) ([]slack.UserGroup, error) {
+	value, err := r.cache.getOrLoad(ctx, "user groups", func() (userGroupCacheValue, error) {
+		groups, err := client.GetUserGroups(ctx)
+		if err != nil {
+			return userGroupCacheValue{}, err
+		}
+		return userGroupCacheValue{groups: groups, members: map[string][]string{}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.groups, nil
+}
+
+func (r *UserGroupRepository) filterUserGroups(groups []slack.UserGroup, filter UserGroupFilter) []slack.UserGroup {
+	query := strings.ToLower(strings.TrimPrefix(filter.Query, "@"))
+
+	var matches []slack.UserGroup
+	for _, group := range groups {
+		if !filter.IncludeDisabled && isUserGroupDisabled(group) {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(group.Handle), query) &&
+			!strings.Contains(strings.ToLower(group.Name), query) {
+			continue
+		}
+		matches = append(matches, group)
+	}
+	return matches
+}
+
+// isUserGroupDisabled reports whether the user group has been disabled, which Slack records as a deletion date
+func isUserGroupDisabled(group slack.UserGroup) bool {
+	return group.DateDelete != 0
+}
+
+func (r *UserGroupRepository) Close() {
+	r.cache.close()
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestUserGroups() []slack.UserGroup {
+	return []slack.UserGroup{
+		{ID: "S1111111", Handle: "platform-team", Name: "Platform Team", UserCount: 3},
+		{ID: "S2222222", Handle: "sre", Name: "Site Reliability", UserCount: 2},
+		{ID: "S3333333", Handle: "old-platform", Name: "Old Platform", DateDelete: slack.JSONTime(1700000000)},
+	}
+}
+
+func TestUserGroupRepository_FindUserGroups(t *testing.T) {
+	t.Run("filters by handle or name and disabled state", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			filter   UserGroupFilter
+			expected []string
+		}{
+			{"excludes disabled by default", UserGroupFilter{}, []string{"S1111111", "S2222222"}},
+			{"includes disabled", UserGroupFilter{IncludeDisabled: true}, []string{"S1111111", "S2222222", "S3333333"}},
+			{"handle substring", UserGroupFilter{Query: "platform", IncludeDisabled: true}, []string{"S1111111", "S3333333"}},
+			{"handle with @ prefix", UserGroupFilter{Query: "@SRE"}, []string{"S2222222"}},
+			{"name substring is case-insensitive", UserGroupFilter{Query: "reliability"}, []string{"S2222222"}},
+			{"no match", UserGroupFilter{Query: "nothing"}, nil},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockClient := &SlackClientMock{}
+				mockClient.On("GetUserGroups", t.Context()).Return(newTestUserGroups(), nil).Once()
+
+				repo := NewUserGroupRepository()
+				t.Cleanup(repo.Close)
+
+				result, err := repo.FindUserGroups(t.Context(), mockClient, tc.filter)
+				assert.NoError(t, err)
+
+				var ids []string
+				for _, group := range result {
+					ids = append(ids, group.ID)
+				}
+				assert.Equal(t, tc.expected, ids)
+			})
+		}
+	})
+
+	t.Run("returns error from Slack", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUserGroups", t.Context()).Return(nil, errors.New("missing required scope: missing_scope"))
+
+		repo := NewUserGroupRepository()
+		t.Cleanup(repo.Close)
+
+		_, err := repo.FindUserGroups(t.Context(), mockClient, UserGroupFilter{})
+		assert.EqualError(t, err, "missing required scope: missing_scope")
+	})
+}
+
+func TestUserGroupRepository_FindByRef(t *testing.T) {
+	mockClient := &SlackClientMock{}
+	mockClient.On("GetUserGroups", t.Context()).Return(newTestUserGroups(), nil).Once()
+
+	repo := NewUserGroupRepository()
+	t.Cleanup(repo.Close)
+
+	testCases := []struct {
+		ref      string
+		expected string
+	}{
+		{"S2222222", "S2222222"},
+		{"sre", "S2222222"},
+		{"@Platform-Team", "S1111111"},
+		{"old-platform", "S3333333"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			group, err := repo.FindByRef(t.Context(), mockClient, tc.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, group.ID)
+		})
+	}
+
+	t.Run("returns nil when not found", func(t *testing.T) {
+		group, err := repo.FindByRef(t.Context(), mockClient, "@nobody")
+		assert.NoError(t, err)
+		assert.Nil(t, group)
+	})
+
+	// usergroups.list is called once across all lookups
+	mockClient.AssertExpectations(t)
+}
+
+func TestUserGroupRepository_GetMembers(t *testing.T) {
+	t.Run("caches members per session and refreshes after ttl expiry", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+
+		now := time.Now()
+
+		repo := NewUserGroupRepository()
+		repo.cache.now = func() time.Time { return now }
+		t.Cleanup(repo.Close)
+
+		ctx1 := WithSessionID(t.Context(), SessionID("session-1"))
+		ctx2 := WithSessionID(t.Context(), SessionID("session-2"))
+
+		mockClient.On("GetUserGroups", mock.Anything).Return(newTestUserGroups(), nil)
+		mockClient.On("GetUserGroupMembers", ctx1, "S2222222").Return([]string{"U1111111", "U2222222"}, nil).Once()
+		mockClient.On("GetUserGroupMembers", ctx2, "S2222222").Return([]string{"U3333333"}, nil).Once()
+
+		_, err := repo.FindByRef(ctx1, mockClient, "sre")
+		assert.NoError(t, err)
+		_, err = repo.FindByRef(ctx2, mockClient, "sre")
+		assert.NoError(t, err)
+
+		members, err := repo.GetMembers(ctx1, mockClient, "S2222222")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"U1111111", "U2222222"}, members)
+
+		members, err = repo.GetMembers(ctx2, mockClient, "S2222222")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"U3333333"}, members)
+
+		// Cached
+		members, err = repo.GetMembers(ctx1, mockClient, "S2222222")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"U1111111", "U2222222"}, members)
+
+		// Refresh after TTL
+		now = now.Add(cacheTTL + time.Second)
+		mockClient.On("GetUserGroupMembers", ctx1, "S2222222").Return([]string{"U4444444"}, nil).Once()
+
+		_, err = repo.FindByRef(ctx1, mockClient, "sre")
+		assert.NoError(t, err)
+		members, err = repo.GetMembers(ctx1, mockClient, "S2222222")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"U4444444"}, members)
+
+		mockClient.AssertExpectations(t)
+	})
+}
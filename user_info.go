@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+// messageUserInfo holds the author names attached to messages when include_user_info is enabled
+type messageUserInfo struct {
+	UserName string
+	RealName string
+}
+
+// lookupMessageUserInfo returns author names for the given user IDs from the cached users.list.
+// IDs are deduplicated, and lookup failures are logged and yield an empty map.
+func (h *Handler) lookupMessageUserInfo(ctx context.Context, client SlackClient, userIDs []string) map[string]messageUserInfo {
+	seen := make(map[string]bool, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	result := make(map[string]messageUserInfo, len(unique))
+	if len(unique) == 0 {
+		return result
+	}
+
+	users, err := h.userRepository.FindByIDs(ctx, client, unique)
+	if err != nil {
+		slog.Debug("failed to look up message authors", "error", err)
+		return result
+	}
+
+	for id, user := range users {
+		result[id] = messageUserInfo{
+			UserName: userDisplayName(user.Profile.DisplayName, user.Profile.RealName, user.Name),
+			RealName: user.Profile.RealName,
+		}
+	}
+	return result
+}
@@ -2,141 +2,249 @@ package main
 
 import (
 	"context"
-	"log/slog"
+	"slices"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/slack-go/slack"
 )
 
+// UserRepository manages user information with session-based caching
+type UserRepository struct {
+	cache *sessionCache[[]slack.User]
+}
+
+// NewUserRepository creates a new UserRepository
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		cache: newSessionCache[[]slack.User](),
+	}
+}
+
+// UserSearchField selects the profile field compared by SearchUsers
+type UserSearchField string
+
 const (
-	cacheTTL           = 30 * time.Minute
-	cacheSweepInterval = 5 * time.Minute
+	UserSearchFieldDisplayName UserSearchField = "display_name"
+	UserSearchFieldRealName    UserSearchField = "real_name"
+	UserSearchFieldEmail       UserSearchField = "email"
+	UserSearchFieldTitle       UserSearchField = "title"
+	// UserSearchFieldAny compares every field above and reports the best match
+	UserSearchFieldAny UserSearchField = "any"
 )
 
-// SessionCache holds cached users for a specific session
-type SessionCache struct {
-	users    []slack.User
-	cachedAt time.Time
+// userSearchFields are the fields compared by UserSearchFieldAny. Earlier fields win ties.
+var userSearchFields = []UserSearchField{
+	UserSearchFieldDisplayName,
+	UserSearchFieldRealName,
+	UserSearchFieldEmail,
+	UserSearchFieldTitle,
 }
 
-// UserRepository manages user information with session-based caching
-type UserRepository struct {
-	sessionCaches map[SessionID]*SessionCache
-	mu            sync.RWMutex
-	stopCh        chan struct{}
-	wg            sync.WaitGroup
-	closeOnce     sync.Once
+// UserMatchMode selects which matches SearchUsers returns
+type UserMatchMode string
 
-	// for test
-	now func() time.Time
-}
+const (
+	UserMatchExact   UserMatchMode = "exact"
+	UserMatchPartial UserMatchMode = "partial"
+	// UserMatchFuzzy also returns values within a small edit distance of the query, for typos and spelling variants
+	UserMatchFuzzy UserMatchMode = "fuzzy"
+)
 
-// NewUserRepository creates a new UserRepository
-func NewUserRepository() *UserRepository {
-	r := &UserRepository{
-		sessionCaches: make(map[SessionID]*SessionCache),
-		stopCh:        make(chan struct{}),
+// matchRank orders how well a field matches a query. Higher is better.
+type matchRank int
 
-		now: time.Now,
-	}
+const (
+	matchNone matchRank = iota
+	matchFuzzy
+	matchSubstring
+	matchPrefix
+	matchExact
+)
 
-	r.wg.Add(1)
-	go r.sweeper()
+// UserMatch is a user found by SearchUsers together with the field that matched
+type UserMatch struct {
+	User  slack.User
+	Field UserSearchField
+	rank  matchRank
+	// distance is the edit distance of a fuzzy match, used to order fuzzy matches
+	distance int
+}
 
-	return r
+// SearchUsers searches users by the given field. Names are compared after normalizeName, so case, width, kana and spaces are ignored.
+// Exact matches come before prefix matches, which come before substring matches and then fuzzy matches.
+func (r *UserRepository) SearchUsers(
+	ctx context.Context,
+	client SlackClient,
+	query string,
+	field UserSearchField,
+	mode UserMatchMode,
+) ([]UserMatch, error) {
+	users, err := r.getUsers(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return r.searchInUsers(users, query, field, mode), nil
 }
 
-// FindByDisplayName searches for users by display name
-func (r *UserRepository) FindByDisplayName(
+// FindByIDs returns the users with the given IDs keyed by user ID. Unknown IDs are omitted.
+func (r *UserRepository) FindByIDs(
 	ctx context.Context,
 	client SlackClient,
-	displayName string,
-	exact bool,
-) ([]slack.User, error) {
-	sessionID := SessionIDFromContext(ctx)
-
-	r.mu.RLock()
-	cache, exists := r.sessionCaches[sessionID]
-	r.mu.RUnlock()
-
-	if exists && !r.isExpired(cache) {
-		slog.Debug("using cached users", "sessionID", sessionID, "cachedAt", cache.cachedAt)
-		return r.searchInUsers(cache.users, displayName, exact), nil
+	userIDs []string,
+) (map[string]slack.User, error) {
+	users, err := r.getUsers(ctx, client)
+	if err != nil {
+		return nil, err
 	}
+	return filterUsersByIDs(users, userIDs), nil
+}
 
-	slog.Debug("fetching users", "sessionID", sessionID)
+// FindCachedByIDs is like FindByIDs but only consults the session cache and never calls Slack.
+// It returns an empty map when the session has no valid cache.
+func (r *UserRepository) FindCachedByIDs(ctx context.Context, userIDs []string) map[string]slack.User {
+	users, ok := r.cache.get(SessionIDFromContext(ctx))
+	if !ok {
+		return map[string]slack.User{}
+	}
+	return filterUsersByIDs(users, userIDs)
+}
 
-	users, err := client.GetUsers(ctx)
+// FindByEmails returns the users with the given emails keyed by lowercased email. Unknown emails are omitted.
+func (r *UserRepository) FindByEmails(
+	ctx context.Context,
+	client SlackClient,
+	emails []string,
+) (map[string]slack.User, error) {
+	users, err := r.getUsers(ctx, client)
 	if err != nil {
 		return nil, err
 	}
+	return filterUsersByEmails(users, emails), nil
+}
 
-	r.mu.Lock()
-	r.sessionCaches[sessionID] = &SessionCache{
-		users:    users,
-		cachedAt: r.now(),
+// FindCachedByEmails is like FindByEmails but only consults the session cache and never calls Slack.
+// It returns an empty map when the session has no valid cache.
+func (r *UserRepository) FindCachedByEmails(ctx context.Context, emails []string) map[string]slack.User {
+	users, ok := r.cache.get(SessionIDFromContext(ctx))
+	if !ok {
+		return map[string]slack.User{}
 	}
-	r.mu.Unlock()
-
-	return r.searchInUsers(users, displayName, exact), nil
+	return filterUsersByEmails(users, emails)
 }
 
-func (r *UserRepository) searchInUsers(users []slack.User, displayName string, exact bool) []slack.User {
-	var matches []slack.User
+// filterUsersByEmails matches emails case-insensitively, since Slack stores them as entered
+func filterUsersByEmails(users []slack.User, emails []string) map[string]slack.User {
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	found := make(map[string]slack.User, len(emails))
 	for _, user := range users {
-		if exact {
-			if user.Profile.DisplayName == displayName {
-				matches = append(matches, user)
-			}
-		} else {
-			if strings.Contains(user.Profile.DisplayName, displayName) {
-				matches = append(matches, user)
-			}
+		email := strings.ToLower(user.Profile.Email)
+		if email != "" && wanted[email] {
+			found[email] = user
 		}
 	}
-	return matches
+	return found
 }
 
-func (r *UserRepository) isExpired(cache *SessionCache) bool {
-	if cache == nil {
-		return true
+func filterUsersByIDs(users []slack.User, userIDs []string) map[string]slack.User {
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
 	}
-	return r.now().Sub(cache.cachedAt) > cacheTTL
+
+	found := make(map[string]slack.User, len(userIDs))
+	for _, user := range users {
+		if wanted[user.ID] {
+			found[user.ID] = user
+		}
+	}
+	return found
+}
+
+// getUsers returns the cached users for the session, loading them from Slack on a miss
+func (r *UserRepository) getUsers(ctx context.Context, client SlackClient) ([]slack.User, error) {
+	return r.cache.getOrLoad(ctx, "users", func() ([]slack.User, error) {
+		return client.GetUsers(ctx)
+	})
 }
 
-func (r *UserRepository) sweeper() {
-	defer r.wg.Done()
+func (r *UserRepository) searchInUsers(users []slack.User, query string, field UserSearchField, mode UserMatchMode) []UserMatch {
+	fields := []UserSearchField{field}
+	if field == UserSearchFieldAny {
+		fields = userSearchFields
+	}
+	query = normalizeName(query)
 
-	ticker := time.NewTicker(cacheSweepInterval)
-	defer ticker.Stop()
+	var matches []UserMatch
+	for _, user := range users {
+		best := UserMatch{User: user}
+		for _, f := range fields {
+			rank, distance := rankMatch(normalizeName(userFieldValue(user, f)), query, mode == UserMatchFuzzy)
+			if rank > best.rank || (rank == best.rank && rank == matchFuzzy && distance < best.distance) {
+				best.Field = f
+				best.rank = rank
+				best.distance = distance
+			}
+		}
+		if best.rank == matchNone || (mode == UserMatchExact && best.rank != matchExact) {
+			continue
+		}
+		matches = append(matches, best)
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			r.sweepExpiredCaches()
-		case <-r.stopCh:
-			return
+	// Stable so that users.list order is kept among equal matches
+	slices.SortStableFunc(matches, func(a, b UserMatch) int {
+		if a.rank != b.rank {
+			return int(b.rank - a.rank)
 		}
+		return a.distance - b.distance
+	})
+	return matches
+}
+
+// userFieldValue returns the value of a profile field. real_name falls back to the account-level name Slack keeps for older users.
+func userFieldValue(user slack.User, field UserSearchField) string {
+	switch field {
+	case UserSearchFieldDisplayName:
+		return user.Profile.DisplayName
+	case UserSearchFieldRealName:
+		if user.Profile.RealName != "" {
+			return user.Profile.RealName
+		}
+		return user.RealName
+	case UserSearchFieldEmail:
+		return user.Profile.Email
+	case UserSearchFieldTitle:
+		return user.Profile.Title
 	}
+	return ""
 }
 
-func (r *UserRepository) sweepExpiredCaches() {
-	now := r.now()
+// rankMatch ranks a normalized value against a normalized query. The distance is set only for fuzzy matches.
+func rankMatch(value, query string, fuzzy bool) (matchRank, int) {
+	switch {
+	case value == "" || query == "":
+		return matchNone, 0
+	case value == query:
+		return matchExact, 0
+	case strings.HasPrefix(value, query):
+		return matchPrefix, 0
+	case strings.Contains(value, query):
+		return matchSubstring, 0
+	}
 
-	r.mu.Lock()
-	for sessionID, cache := range r.sessionCaches {
-		if now.Sub(cache.cachedAt) > cacheTTL {
-			delete(r.sessionCaches, sessionID)
+	if fuzzy {
+		queryRunes := []rune(query)
+		if distance := fuzzyDistance(queryRunes, []rune(value)); distance <= maxFuzzyDistance(len(queryRunes)) {
+			return matchFuzzy, distance
 		}
 	}
-	r.mu.Unlock()
+	return matchNone, 0
 }
 
 func (r *UserRepository) Close() {
-	r.closeOnce.Do(func() {
-		close(r.stopCh)
-	})
-	r.wg.Wait()
+	r.cache.close()
 }
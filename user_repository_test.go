@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func TestUserRepository_FindByDisplayName(t *testing.T) {
+func TestUserRepository_SearchUsers(t *testing.T) {
 	t.Run("returns users when display name matches exactly", func(t *testing.T) {
 		mockClient := &SlackClientMock{}
 		users := []slack.User{
@@ -35,12 +35,12 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		repo := NewUserRepository()
 		t.Cleanup(repo.Close)
 
-		result, err := repo.FindByDisplayName(t.Context(), mockClient, "jdoe", true)
+		result, err := repo.SearchUsers(t.Context(), mockClient, "jdoe", UserSearchFieldDisplayName, UserMatchExact)
 
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
-		assert.Equal(t, "U1234567", result[0].ID)
-		assert.Equal(t, "jdoe", result[0].Profile.DisplayName)
+		assert.Equal(t, "U1234567", result[0].User.ID)
+		assert.Equal(t, "jdoe", result[0].User.Profile.DisplayName)
 		mockClient.AssertExpectations(t)
 	})
 
@@ -78,14 +78,14 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		t.Cleanup(repo.Close)
 
 		// Search for "john" should match john.doe and jane.johnson
-		result, err := repo.FindByDisplayName(t.Context(), mockClient, "john", false)
+		result, err := repo.SearchUsers(t.Context(), mockClient, "john", UserSearchFieldDisplayName, UserMatchPartial)
 
 		assert.NoError(t, err)
 		assert.Len(t, result, 2)
 
 		foundUsers := make(map[string]bool)
-		for _, user := range result {
-			foundUsers[user.ID] = true
+		for _, match := range result {
+			foundUsers[match.User.ID] = true
 		}
 		assert.True(t, foundUsers["U1234567"]) // john.doe
 		assert.True(t, foundUsers["U3456789"]) // jane.johnson
@@ -111,15 +111,15 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		t.Cleanup(repo.Close)
 
 		// First call - should call API
-		result1, err1 := repo.FindByDisplayName(t.Context(), mockClient, "jdoe", true)
+		result1, err1 := repo.SearchUsers(t.Context(), mockClient, "jdoe", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err1)
 		assert.Len(t, result1, 1)
 
 		// Second call - should use cache, not call API again
-		result2, err2 := repo.FindByDisplayName(t.Context(), mockClient, "jdoe", true)
+		result2, err2 := repo.SearchUsers(t.Context(), mockClient, "jdoe", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err2)
 		assert.Len(t, result2, 1)
-		assert.Equal(t, result1[0].ID, result2[0].ID)
+		assert.Equal(t, result1[0].User.ID, result2[0].User.ID)
 
 		mockClient.AssertExpectations(t)
 	})
@@ -159,36 +159,36 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		mockClient.On("GetUsers", ctx2, mock.Anything).Return(users2, nil).Once()
 
 		// First call with session 1
-		result1, err1 := repo.FindByDisplayName(ctx1, mockClient, "session1user", true)
+		result1, err1 := repo.SearchUsers(ctx1, mockClient, "session1user", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err1)
 		assert.Len(t, result1, 1)
-		assert.Equal(t, "U1111111", result1[0].ID)
+		assert.Equal(t, "U1111111", result1[0].User.ID)
 
 		// First call with session 2 - should call API because different session
-		result2, err2 := repo.FindByDisplayName(ctx2, mockClient, "session2user", true)
+		result2, err2 := repo.SearchUsers(ctx2, mockClient, "session2user", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err2)
 		assert.Len(t, result2, 1)
-		assert.Equal(t, "U2222222", result2[0].ID)
+		assert.Equal(t, "U2222222", result2[0].User.ID)
 
-		// sessionCaches structure should be expected
-		assert.Len(t, repo.sessionCaches, 2)
-		cache1 := repo.sessionCaches[SessionID("session-1")]
+		// cache entries structure should be expected
+		assert.Len(t, repo.cache.entries, 2)
+		cache1 := repo.cache.entries[SessionID("session-1")]
 		if assert.NotNil(t, cache1) {
-			assert.Equal(t, users1, cache1.users)
+			assert.Equal(t, users1, cache1.value)
 		}
-		cache2 := repo.sessionCaches[SessionID("session-2")]
+		cache2 := repo.cache.entries[SessionID("session-2")]
 		if assert.NotNil(t, cache2) {
-			assert.Equal(t, users2, cache2.users)
+			assert.Equal(t, users2, cache2.value)
 		}
 
 		// Second call with session 1 - should use cache
-		result3, err3 := repo.FindByDisplayName(ctx1, mockClient, "session1user", true)
+		result3, err3 := repo.SearchUsers(ctx1, mockClient, "session1user", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err3)
 		assert.Len(t, result3, 1)
-		assert.Equal(t, "U1111111", result3[0].ID)
+		assert.Equal(t, "U1111111", result3[0].User.ID)
 
 		// Verify that session 1 context doesn't return session 2 data
-		result4, err4 := repo.FindByDisplayName(ctx1, mockClient, "session2user", true)
+		result4, err4 := repo.SearchUsers(ctx1, mockClient, "session2user", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err4)
 		assert.Len(t, result4, 0) // Should not find session2user in session1 cache
 
@@ -221,7 +221,7 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		now := time.Now()
 
 		repo := NewUserRepository()
-		repo.now = func() time.Time { return now }
+		repo.cache.now = func() time.Time { return now }
 		t.Cleanup(repo.Close)
 
 		ctx := WithSessionID(t.Context(), SessionID("session-refresh"))
@@ -229,28 +229,28 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		mockClient.On("GetUsers", ctx, mock.Anything).Return(usersInitial, nil).Once()
 
 		// First call populates cache
-		result1, err1 := repo.FindByDisplayName(ctx, mockClient, "initial", true)
+		result1, err1 := repo.SearchUsers(ctx, mockClient, "initial", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err1)
 		assert.Len(t, result1, 1)
-		assert.Equal(t, "U1111111", result1[0].ID)
+		assert.Equal(t, "U1111111", result1[0].User.ID)
 
 		mockClient.On("GetUsers", ctx, mock.Anything).Return(usersRefreshed, nil).Once()
 
 		now = now.Add(cacheTTL)
 
 		// Use cache yet
-		result2, err2 := repo.FindByDisplayName(ctx, mockClient, "initial", true)
+		result2, err2 := repo.SearchUsers(ctx, mockClient, "initial", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err2)
 		assert.Len(t, result2, 1)
-		assert.Equal(t, "U1111111", result2[0].ID)
+		assert.Equal(t, "U1111111", result2[0].User.ID)
 
 		// Advance time beyond TTL and expect refreshed data
 		now = now.Add(time.Second)
 
-		result3, err3 := repo.FindByDisplayName(ctx, mockClient, "refreshed", true)
+		result3, err3 := repo.SearchUsers(ctx, mockClient, "refreshed", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err3)
 		assert.Len(t, result3, 1)
-		assert.Equal(t, "U2222222", result3[0].ID)
+		assert.Equal(t, "U2222222", result3[0].User.ID)
 
 		mockClient.AssertExpectations(t)
 	})
@@ -272,7 +272,7 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 		repo := NewUserRepository()
 		t.Cleanup(repo.Close)
 
-		result, err := repo.FindByDisplayName(t.Context(), mockClient, "Not Found User", true)
+		result, err := repo.SearchUsers(t.Context(), mockClient, "Not Found User", UserSearchFieldDisplayName, UserMatchExact)
 
 		assert.NoError(t, err)
 		assert.Len(t, result, 0)
@@ -280,6 +280,217 @@ func TestUserRepository_FindByDisplayName(t *testing.T) {
 	})
 }
 
+func TestUserRepository_SearchUsers_fields(t *testing.T) {
+	users := []slack.User{
+		{ID: "U1", Profile: slack.UserProfile{DisplayName: "", RealName: "Anna Kowalski", Email: "anna.k@example.com", Title: "SRE"}},
+		{ID: "U2", Profile: slack.UserProfile{DisplayName: "ann", RealName: "Ann Lee", Email: "lee@example.com", Title: "Engineering Manager"}},
+		{ID: "U3", Profile: slack.UserProfile{DisplayName: "joanna", RealName: "Joanna Smith", Email: "joanna@example.com", Title: "Senior SRE"}},
+		{ID: "U4", RealName: "Legacy Anne", Profile: slack.UserProfile{Email: "legacy@example.com"}},
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		field    UserSearchField
+		mode     UserMatchMode
+		expected []string
+		fields   []UserSearchField
+	}{
+		{
+			"display_name partial is ranked exact, prefix then substring",
+			"ANN", UserSearchFieldDisplayName, UserMatchPartial,
+			[]string{"U2", "U3"},
+			[]UserSearchField{UserSearchFieldDisplayName, UserSearchFieldDisplayName},
+		},
+		{
+			"real_name finds users without a display name",
+			"anna", UserSearchFieldRealName, UserMatchPartial,
+			[]string{"U1", "U3"},
+			[]UserSearchField{UserSearchFieldRealName, UserSearchFieldRealName},
+		},
+		{
+			"real_name falls back to the account name",
+			"legacy anne", UserSearchFieldRealName, UserMatchExact,
+			[]string{"U4"},
+			[]UserSearchField{UserSearchFieldRealName},
+		},
+		{
+			"email exact",
+			"Lee@Example.com", UserSearchFieldEmail, UserMatchExact,
+			[]string{"U2"},
+			[]UserSearchField{UserSearchFieldEmail},
+		},
+		{
+			"title exact only",
+			"sre", UserSearchFieldTitle, UserMatchExact,
+			[]string{"U1"},
+			[]UserSearchField{UserSearchFieldTitle},
+		},
+		{
+			"any reports the best matching field",
+			"ann", UserSearchFieldAny, UserMatchPartial,
+			[]string{"U2", "U1", "U3", "U4"},
+			[]UserSearchField{UserSearchFieldDisplayName, UserSearchFieldRealName, UserSearchFieldDisplayName, UserSearchFieldRealName},
+		},
+		{
+			"no match",
+			"nobody", UserSearchFieldAny, UserMatchPartial,
+			nil, nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &SlackClientMock{}
+			mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return(users, nil)
+
+			repo := NewUserRepository()
+			t.Cleanup(repo.Close)
+
+			result, err := repo.SearchUsers(t.Context(), mockClient, tc.query, tc.field, tc.mode)
+			assert.NoError(t, err)
+
+			var ids []string
+			var fields []UserSearchField
+			for _, match := range result {
+				ids = append(ids, match.User.ID)
+				fields = append(fields, match.Field)
+			}
+			assert.Equal(t, tc.expected, ids)
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestUserRepository_SearchUsers_normalized(t *testing.T) {
+	users := []slack.User{
+		{ID: "U1", Profile: slack.UserProfile{DisplayName: "ヤマダ タロウ", RealName: "山田 太郎"}},
+		{ID: "U2", Profile: slack.UserProfile{DisplayName: "ｽｽﾞｷ", RealName: "鈴木 花子"}},
+		{ID: "U3", Profile: slack.UserProfile{DisplayName: "Ｔａｎａｋａ", RealName: "田中　一郎"}},
+		{ID: "U4", Profile: slack.UserProfile{DisplayName: "さとう", RealName: "Sato Kenji"}},
+		{ID: "U5", Profile: slack.UserProfile{DisplayName: "ﾊﾟﾝﾀﾞ", RealName: "Panda"}},
+		{ID: "U6", Profile: slack.UserProfile{DisplayName: "サトシ", RealName: "佐藤 聡"}},
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		field    UserSearchField
+		mode     UserMatchMode
+		expected []string
+	}{
+		{"hiragana query matches katakana name", "やまだ たろう", UserSearchFieldDisplayName, UserMatchExact, []string{"U1"}},
+		{"spaces are ignored", "ヤマダタロウ", UserSearchFieldDisplayName, UserMatchExact, []string{"U1"}},
+		{"ideographic space is ignored", "田中一郎", UserSearchFieldRealName, UserMatchExact, []string{"U3"}},
+		{"full-width query matches half-width katakana", "スズキ", UserSearchFieldDisplayName, UserMatchExact, []string{"U2"}},
+		{"half-width sound marks compose", "ぱんだ", UserSearchFieldDisplayName, UserMatchExact, []string{"U5"}},
+		{"full-width latin matches regardless of case", "TANAKA", UserSearchFieldDisplayName, UserMatchExact, []string{"U3"}},
+		{"katakana query matches hiragana name", "サトウ", UserSearchFieldDisplayName, UserMatchExact, []string{"U4"}},
+		{"kana prefix match", "ﾔﾏﾀﾞ", UserSearchFieldDisplayName, UserMatchPartial, []string{"U1"}},
+		{"kanji substring match", "太郎", UserSearchFieldRealName, UserMatchPartial, []string{"U1"}},
+		{"typo is not matched without fuzzy", "やまたたろう", UserSearchFieldDisplayName, UserMatchPartial, nil},
+		{"fuzzy tolerates a wrong sound mark", "やまたたろう", UserSearchFieldDisplayName, UserMatchFuzzy, []string{"U1"}},
+		{"fuzzy tolerates a latin typo", "Sato Kenzi", UserSearchFieldRealName, UserMatchFuzzy, []string{"U4"}},
+		{"fuzzy ranks exact matches first", "さとう", UserSearchFieldDisplayName, UserMatchFuzzy, []string{"U4", "U6"}},
+		{"fuzzy ignores typos in short queries", "やな", UserSearchFieldDisplayName, UserMatchFuzzy, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &SlackClientMock{}
+			mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return(users, nil)
+
+			repo := NewUserRepository()
+			t.Cleanup(repo.Close)
+
+			result, err := repo.SearchUsers(t.Context(), mockClient, tc.query, tc.field, tc.mode)
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, match := range result {
+				ids = append(ids, match.User.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestUserRepository_FindCachedByIDs(t *testing.T) {
+	t.Run("returns empty without calling Slack when nothing is cached", func(t *testing.T) {
+		repo := NewUserRepository()
+		t.Cleanup(repo.Close)
+
+		assert.Empty(t, repo.FindCachedByIDs(t.Context(), []string{"U1234567"}))
+	})
+
+	t.Run("returns cached users for the session", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return([]slack.User{
+			{ID: "U1234567", Profile: slack.UserProfile{DisplayName: "jdoe"}},
+			{ID: "U2345678", Profile: slack.UserProfile{DisplayName: "jane.s"}},
+		}, nil).Once()
+
+		repo := NewUserRepository()
+		t.Cleanup(repo.Close)
+		_, err := repo.FindByIDs(t.Context(), mockClient, []string{"U1234567"})
+		assert.NoError(t, err)
+
+		result := repo.FindCachedByIDs(t.Context(), []string{"U2345678", "U9999999"})
+		assert.Len(t, result, 1)
+		assert.Equal(t, "jane.s", result["U2345678"].Profile.DisplayName)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("ignores expired caches", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return([]slack.User{
+			{ID: "U1234567", Profile: slack.UserProfile{DisplayName: "jdoe"}},
+		}, nil).Once()
+
+		repo := NewUserRepository()
+		t.Cleanup(repo.Close)
+		baseTime := time.Now()
+		repo.cache.now = func() time.Time { return baseTime }
+		_, err := repo.FindByIDs(t.Context(), mockClient, []string{"U1234567"})
+		assert.NoError(t, err)
+
+		repo.cache.now = func() time.Time { return baseTime.Add(cacheTTL + time.Second) }
+		assert.Empty(t, repo.FindCachedByIDs(t.Context(), []string{"U1234567"}))
+	})
+}
+
+func TestUserRepository_FindByEmails(t *testing.T) {
+	t.Run("matches emails case-insensitively", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("GetUsers", t.Context(), []slack.GetUsersOption(nil)).Return([]slack.User{
+			{ID: "U1234567", Profile: slack.UserProfile{Email: "John.Doe@example.com"}},
+			{ID: "U2345678", Profile: slack.UserProfile{Email: "jane@example.com"}},
+			{ID: "U3456789"},
+		}, nil).Once()
+
+		repo := NewUserRepository()
+		t.Cleanup(repo.Close)
+
+		result, err := repo.FindByEmails(t.Context(), mockClient, []string{"john.doe@EXAMPLE.com", "nobody@example.com", ""})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "U1234567", result["john.doe@example.com"].ID)
+
+		// Cached
+		cached := repo.FindCachedByEmails(t.Context(), []string{"Jane@example.com"})
+		assert.Len(t, cached, 1)
+		assert.Equal(t, "U2345678", cached["jane@example.com"].ID)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns empty without calling Slack when nothing is cached", func(t *testing.T) {
+		repo := NewUserRepository()
+		t.Cleanup(repo.Close)
+
+		assert.Empty(t, repo.FindCachedByEmails(t.Context(), []string{"jane@example.com"}))
+	})
+}
+
 func TestUserRepository_sweepExpiredCaches(t *testing.T) {
 	t.Run("removes expired cache via sweeper", func(t *testing.T) {
 		mockClient := &SlackClientMock{}
@@ -297,7 +508,7 @@ func TestUserRepository_sweepExpiredCaches(t *testing.T) {
 		now := time.Now()
 
 		repo := NewUserRepository()
-		repo.now = func() time.Time { return now }
+		repo.cache.now = func() time.Time { return now }
 		t.Cleanup(repo.Close)
 
 		ctx := WithSessionID(t.Context(), SessionID("session-clean"))
@@ -305,21 +516,21 @@ func TestUserRepository_sweepExpiredCaches(t *testing.T) {
 		mockClient.On("GetUsers", ctx, mock.Anything).Return(users, nil).Once()
 
 		// Prime cache
-		_, err := repo.FindByDisplayName(ctx, mockClient, "foo", true)
+		_, err := repo.SearchUsers(ctx, mockClient, "foo", UserSearchFieldDisplayName, UserMatchExact)
 		assert.NoError(t, err)
-		assert.Len(t, repo.sessionCaches, 1)
-		assert.Equal(t, users, repo.sessionCaches[SessionID("session-clean")].users)
-		assert.Equal(t, now, repo.sessionCaches[SessionID("session-clean")].cachedAt)
+		assert.Len(t, repo.cache.entries, 1)
+		assert.Equal(t, users, repo.cache.entries[SessionID("session-clean")].value)
+		assert.Equal(t, now, repo.cache.entries[SessionID("session-clean")].cachedAt)
 
 		// Advance time beyond TTL
 		now = now.Add(cacheTTL + time.Second)
 
 		// Run sweeper
-		repo.sweepExpiredCaches()
+		repo.cache.sweepExpired()
 
-		repo.mu.RLock()
-		_, exists := repo.sessionCaches[SessionID("session-clean")]
-		repo.mu.RUnlock()
+		repo.cache.mu.RLock()
+		_, exists := repo.cache.entries[SessionID("session-clean")]
+		repo.cache.mu.RUnlock()
 		assert.False(t, exists)
 
 		mockClient.AssertExpectations(t)
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"strings"
+)
+
+// WorkspaceRepository manages workspace information with session-based caching
+type WorkspaceRepository struct {
+	// cache holds the workspace URL of each session
+	cache *sessionCache[string]
+}
+
+// NewWorkspaceRepository creates a new WorkspaceRepository
+func NewWorkspaceRepository() *WorkspaceRepository {
+	return &WorkspaceRepository{
+		cache: newSessionCache[string](),
+	}
+}
+
+// GetWorkspaceURL returns the workspace URL (e.g., "https://workspace.slack.com") discovered via auth.test
+func (r *WorkspaceRepository) GetWorkspaceURL(ctx context.Context, client SlackClient) (string, error) {
+	return r.cache.getOrLoad(ctx, "workspace URL", func() (string, error) {
+		auth, err := client.AuthTest(ctx)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(auth.URL, "/"), nil
+	})
+}
+
+func (r *WorkspaceRepository) Close() {
+	r.cache.close()
+}
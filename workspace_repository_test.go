@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceRepository_GetWorkspaceURL(t *testing.T) {
+	t.Run("returns the auth.test URL without trailing slash and caches it per session", func(t *testing.T) {
+		ctx1 := WithSessionID(t.Context(), SessionID("session-1"))
+		ctx2 := WithSessionID(t.Context(), SessionID("session-2"))
+
+		mockClient := &SlackClientMock{}
+		mockClient.On("AuthTest", ctx1).Return(&slack.AuthTestResponse{URL: "https://one.slack.com/"}, nil).Once()
+		mockClient.On("AuthTest", ctx2).Return(&slack.AuthTestResponse{URL: "https://two.slack.com/"}, nil).Once()
+
+		repo := NewWorkspaceRepository()
+		t.Cleanup(repo.Close)
+
+		url, err := repo.GetWorkspaceURL(ctx1, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://one.slack.com", url)
+
+		url, err = repo.GetWorkspaceURL(ctx2, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://two.slack.com", url)
+
+		// Cached
+		url, err = repo.GetWorkspaceURL(ctx1, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://one.slack.com", url)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("returns the auth.test error without caching it", func(t *testing.T) {
+		mockClient := &SlackClientMock{}
+		mockClient.On("AuthTest", mock.Anything).Return(nil, errors.New("authentication failed: invalid_auth")).Once()
+		mockClient.On("AuthTest", mock.Anything).Return(&slack.AuthTestResponse{URL: "https://workspace.slack.com/"}, nil).Once()
+
+		repo := NewWorkspaceRepository()
+		t.Cleanup(repo.Close)
+
+		_, err := repo.GetWorkspaceURL(t.Context(), mockClient)
+		assert.EqualError(t, err, "authentication failed: invalid_auth")
+
+		url, err := repo.GetWorkspaceURL(t.Context(), mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://workspace.slack.com", url)
+	})
+}